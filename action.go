@@ -5,6 +5,7 @@ package names
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -12,20 +13,99 @@ import (
 
 const ActionTagKind = "action"
 
+// ActionTag represents a tag used to describe an action. Actions are
+// identified either by a UUID or, in newer models, by a sequence
+// number.
 type ActionTag struct {
 	// Tags that are serialized need to have fields exported.
 	ID utils.UUID
+
+	// Seq holds the sequence number of an action identified
+	// by number rather than UUID; it is empty otherwise.
+	Seq string
 }
 
-// NewActionTag returns the tag of an action with the given id (UUID).
+// actionStateIdSeparator separates the receiver and the action
+// id in the internal ids of actions, e.g. "mysql/0_a_<uuid>".
+const actionStateIdSeparator = "_a_"
+
+// NewActionTag returns the tag of an action with the given id,
+// which may be a UUID or a sequence number.
 func NewActionTag(id string) ActionTag {
-	uuid, err := utils.UUIDFromString(id)
-	if err != nil {
-		panic(err)
+	if validNumber.MatchString(id) {
+		return ActionTag{Seq: id}
+	}
+	uuid, ok := uuidFromString(id)
+	if !ok {
+		panic(fmt.Errorf("invalid UUID: %q", id))
 	}
 	return ActionTag{ID: uuid}
 }
 
+// ActionStateId identifies an action by its receiver, the unit or
+// machine it runs on, as in the internal ids of actions. Action tags
+// do not record their receivers.
+type ActionStateId struct {
+	Receiver Tag
+	Action   ActionTag
+}
+
+// NewActionStateId returns the internal id of the given action
+// running on the given receiver, which must be a unit or machine.
+func NewActionStateId(receiver Tag, action ActionTag) ActionStateId {
+	switch receiver.(type) {
+	case UnitTag, MachineTag:
+	default:
+		panic(fmt.Sprintf("%q is not a valid action receiver", receiver))
+	}
+	return ActionStateId{Receiver: receiver, Action: action}
+}
+
+// ParseActionStateId parses the internal id of an action, of
+// the form "<receiver>_a_<id>".
+func ParseActionStateId(stateId string) (ActionStateId, error) {
+	i := strings.Index(stateId, actionStateIdSeparator)
+	if i == -1 {
+		return ActionStateId{}, fmt.Errorf("%q is not a valid action id: missing receiver", stateId)
+	}
+	receiver, err := ActionReceiverTag(stateId[:i])
+	if err != nil {
+		return ActionStateId{}, fmt.Errorf("%q is not a valid action id: %v", stateId, err)
+	}
+	id := stateId[i+len(actionStateIdSeparator):]
+	if !IsValidAction(id) {
+		return ActionStateId{}, fmt.Errorf("%q is not a valid action id", stateId)
+	}
+	return NewActionStateId(receiver, NewActionTag(id)), nil
+}
+
+// String returns the internal id of the action,
+// of the form "<receiver>_a_<id>".
+func (id ActionStateId) String() string {
+	var receiver string
+	if !isNilTag(id.Receiver) {
+		receiver = id.Receiver.Id()
+	}
+	return receiver + actionStateIdSeparator + id.Action.Id()
+}
+
+// Validate returns an error if the receiver or action
+// tag is not valid.
+func (id ActionStateId) Validate() error {
+	if isNilTag(id.Receiver) {
+		return fmt.Errorf("action %q has no receiver", id.Action.Id())
+	}
+	switch id.Receiver.(type) {
+	case UnitTag, MachineTag:
+	default:
+		return fmt.Errorf("%q is not a valid action receiver", id.Receiver)
+	}
+	if err := Validate(id.Receiver); err != nil {
+		return err
+	}
+	return Validate(id.Action)
+}
+
 // ParseActionTag parses an action tag string.
 func ParseActionTag(actionTag string) (ActionTag, error) {
 	tag, err := ParseTag(actionTag)
@@ -41,11 +121,35 @@ func ParseActionTag(actionTag string) (ActionTag, error) {
 
 func (t ActionTag) String() string { return t.Kind() + "-" + t.Id() }
 func (t ActionTag) Kind() string   { return ActionTagKind }
-func (t ActionTag) Id() string     { return t.ID.String() }
+func (t ActionTag) Id() string {
+	if t.IsNumeric() {
+		return t.Seq
+	}
+	return t.ID.String()
+}
+
+func (t ActionTag) Validate() error     { return validateTag(t) }
+func (t ActionTag) PathSegment() string { return pathSegment(t) }
+
+// IsNumeric returns whether the action is identified
+// by a sequence number rather than a UUID.
+func (t ActionTag) IsNumeric() bool {
+	return t.Seq != ""
+}
+
+// UUID returns the UUID identifying the action, and
+// whether the action is identified by a UUID.
+func (t ActionTag) UUID() (utils.UUID, bool) {
+	if t.IsNumeric() {
+		return utils.UUID{}, false
+	}
+	return t.ID, true
+}
 
-// IsValidAction returns whether id is a valid action id (UUID).
+// IsValidAction returns whether id is a valid action id,
+// either a UUID or a sequence number.
 func IsValidAction(id string) bool {
-	return utils.IsValidUUIDString(id)
+	return validNumber.MatchString(id) || IsValidUUID(id)
 }
 
 // ActionReceiverTag returns an ActionReceiver Tag from a
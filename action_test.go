@@ -22,7 +22,9 @@ var parseActionTagTests = []struct {
 	{tag: "", err: names.InvalidTagError("", "")},
 	{tag: "action-f47ac10b-58cc-4372-a567-0e02b2c3d479", expected: names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")},
 	{tag: "action-012345678", err: names.InvalidTagError("action-012345678", "action")},
-	{tag: "action-1234567", err: names.InvalidTagError("action-1234567", "action")},
+	{tag: "action-1234567", expected: names.NewActionTag("1234567")},
+	{tag: "action-0", expected: names.NewActionTag("0")},
+	{tag: "action--1", err: names.InvalidTagError("action--1", "action")},
 	{tag: "bob", err: names.InvalidTagError("bob", "")},
 	{tag: "service-ned", err: names.InvalidTagError("service-ned", names.ActionTagKind)}}
 
@@ -40,6 +42,97 @@ func (s *actionSuite) TestParseActionTag(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestActionTagIdForms(c *gc.C) {
+	tag := names.NewActionTag("42")
+	c.Check(tag.IsNumeric(), jc.IsTrue)
+	c.Check(tag.Id(), gc.Equals, "42")
+	c.Check(tag.String(), gc.Equals, "action-42")
+	_, ok := tag.UUID()
+	c.Check(ok, jc.IsFalse)
+
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	tag = names.NewActionTag(id)
+	c.Check(tag.IsNumeric(), jc.IsFalse)
+	c.Check(tag.Id(), gc.Equals, id)
+	uuid, ok := tag.UUID()
+	c.Check(ok, jc.IsTrue)
+	c.Check(uuid.String(), gc.Equals, id)
+}
+
+func (s *actionSuite) TestIsValidAction(c *gc.C) {
+	c.Check(names.IsValidAction("0"), jc.IsTrue)
+	c.Check(names.IsValidAction("123"), jc.IsTrue)
+	c.Check(names.IsValidAction("f47ac10b-58cc-4372-a567-0e02b2c3d479"), jc.IsTrue)
+	c.Check(names.IsValidAction("012"), jc.IsFalse)
+	c.Check(names.IsValidAction("-1"), jc.IsFalse)
+	c.Check(names.IsValidAction(""), jc.IsFalse)
+	c.Check(names.IsValidAction("f47ac10b"), jc.IsFalse)
+}
+
+func (s *actionSuite) TestActionStateId(c *gc.C) {
+	id := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	stateId := names.NewActionStateId(names.NewUnitTag("mysql/0"), names.NewActionTag(id))
+	c.Check(stateId.Receiver, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Check(stateId.Action, gc.Equals, names.NewActionTag(id))
+	c.Check(stateId.String(), gc.Equals, "mysql/0_a_"+id)
+	c.Check(stateId.Validate(), jc.ErrorIsNil)
+
+	stateId = names.NewActionStateId(names.NewMachineTag("0/lxd/1"), names.NewActionTag("7"))
+	c.Check(stateId.String(), gc.Equals, "0/lxd/1_a_7")
+
+	stateId = names.NewActionStateId(names.UnitTag{}, names.NewActionTag(id))
+	c.Check(stateId.Validate(), gc.ErrorMatches, `"unit-" is not a valid unit tag`)
+	stateId = names.ActionStateId{Action: names.NewActionTag("7")}
+	c.Check(stateId.Validate(), gc.ErrorMatches, `action "7" has no receiver`)
+	c.Check(stateId.String(), gc.Equals, "_a_7")
+
+	c.Check(func() { names.NewActionStateId(names.NewServiceTag("mysql"), names.NewActionTag("7")) },
+		gc.PanicMatches, `"service-mysql" is not a valid action receiver`)
+}
+
+func (s *actionSuite) TestParseActionStateId(c *gc.C) {
+	for i, test := range []struct {
+		stateId  string
+		receiver names.Tag
+		id       string
+		err      string
+	}{{
+		stateId:  "mysql/0_a_f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		receiver: names.NewUnitTag("mysql/0"),
+		id:       "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+	}, {
+		stateId:  "2_a_12",
+		receiver: names.NewMachineTag("2"),
+		id:       "12",
+	}, {
+		stateId: "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		err:     `"f47ac10b-58cc-4372-a567-0e02b2c3d479" is not a valid action id: missing receiver`,
+	}, {
+		stateId: "mysql_a_12",
+		err:     `"mysql_a_12" is not a valid action id: invalid actionreceiver name "mysql"`,
+	}, {
+		stateId: "mysql/0_a_x",
+		err:     `"mysql/0_a_x" is not a valid action id`,
+	}} {
+		c.Logf("test %d: %q", i, test.stateId)
+		stateId, err := names.ParseActionStateId(test.stateId)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(stateId.Receiver, gc.Equals, test.receiver)
+		c.Check(stateId.Action, gc.Equals, names.NewActionTag(test.id))
+		c.Check(stateId.String(), gc.Equals, test.stateId)
+
+		// The action tag does not depend on the receiver, so it
+		// round trips through its string.
+		tag, err := names.ParseActionTag(stateId.Action.String())
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, stateId.Action)
+	}
+}
+
 func (s *actionSuite) TestActionReceiverTag(c *gc.C) {
 	testCases := []struct {
 		name     string
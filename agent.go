@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+)
+
+// TagToAgentDirName returns the name of the directory, within the
+// agents data directory (e.g. /var/lib/juju/agents), that holds the
+// state of the agent for the entity with the given tag.
+// For example, the directory for unit mysql/0 is "unit-mysql-0".
+// Only machines and units have agents, and so directories. It returns
+// the empty string if tag is nil. It is the inverse of
+// AgentDirNameToTag.
+func TagToAgentDirName(tag AgentTag) string {
+	if isNilTag(tag) {
+		return ""
+	}
+	return tag.String()
+}
+
+// AgentDirNameToTag returns the tag of the agent whose state is held
+// in the agents data directory entry with the given name. Only machine
+// and unit agents have such directories, so any other name results
+// in an error.
+func AgentDirNameToTag(name string) (AgentTag, error) {
+	tag, err := ParseTag(name)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid agent directory name", name)
+	}
+	agentTag, ok := tag.(AgentTag)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid agent directory name", name)
+	}
+	return agentTag, nil
+}
+
+// AgentTag is implemented by the tags of entities that run agents
+// and log in to the API as such: machines and units. Only tags
+// in this package implement it.
+type AgentTag interface {
+	Tag
+	agentTag()
+}
+
+func (MachineTag) agentTag() {}
+func (UnitTag) agentTag()    {}
+
+// IsAgentTag returns whether tag is the tag of an entity that runs
+// an agent. It is the same as checking whether tag is an AgentTag.
+func IsAgentTag(tag Tag) bool {
+	_, ok := tag.(AgentTag)
+	return ok
+}
+
+// machineOrUnitKinds describes the kinds accepted
+// by ParseMachineOrUnitTag, for use in errors.
+const machineOrUnitKinds = MachineTagKind + " or " + UnitTagKind
+
+// ParseMachineOrUnitTag parses a machine or unit tag string. The
+// result is always either a MachineTag or a UnitTag, so callers may
+// switch on its type without a default case. Malformed machine and
+// unit tags are reported as by ParseTag, and all other strings with
+// an error naming both kinds.
+func ParseMachineOrUnitTag(s string) (AgentTag, error) {
+	tag, err := ParseTag(s)
+	if err != nil {
+		if HasKindPrefix(s, MachineTagKind) || HasKindPrefix(s, UnitTagKind) {
+			return nil, err
+		}
+		return nil, invalidTagError(s, machineOrUnitKinds)
+	}
+	switch tag := tag.(type) {
+	case MachineTag:
+		return tag, nil
+	case UnitTag:
+		return tag, nil
+	}
+	return nil, invalidTagError(s, machineOrUnitKinds)
+}
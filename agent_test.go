@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type agentSuite struct{}
+
+var _ = gc.Suite(&agentSuite{})
+
+func (s *agentSuite) TestTagToAgentDirName(c *gc.C) {
+	c.Assert(names.TagToAgentDirName(names.NewMachineTag("0")), gc.Equals, "machine-0")
+	c.Assert(names.TagToAgentDirName(names.NewMachineTag("0/lxc/1")), gc.Equals, "machine-0-lxc-1")
+	c.Assert(names.TagToAgentDirName(names.NewUnitTag("mysql/0")), gc.Equals, "unit-mysql-0")
+	c.Assert(names.TagToAgentDirName(nil), gc.Equals, "")
+}
+
+func (s *agentSuite) TestAgentDirNameToTag(c *gc.C) {
+	for i, test := range []struct {
+		name   string
+		expect names.Tag
+		err    string
+	}{
+		{name: "machine-0", expect: names.NewMachineTag("0")},
+		{name: "machine-0-lxc-1", expect: names.NewMachineTag("0/lxc/1")},
+		{name: "unit-rabbitmq-server-0", expect: names.NewUnitTag("rabbitmq-server/0")},
+		{name: "service-mysql", err: `"service-mysql" is not a valid agent directory name`},
+		{name: "tools", err: `"tools" is not a valid agent directory name`},
+		{name: "", err: `"" is not a valid agent directory name`},
+	} {
+		c.Logf("test %d: %q", i, test.name)
+		tag, err := names.AgentDirNameToTag(test.name)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tag, gc.IsNil)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, test.expect)
+		c.Check(names.TagToAgentDirName(tag), gc.Equals, test.name)
+	}
+}
+
+func (s *agentSuite) TestIsAgentTag(c *gc.C) {
+	for i, test := range []struct {
+		tag    names.Tag
+		expect bool
+	}{
+		{tag: names.NewMachineTag("0"), expect: true},
+		{tag: names.NewMachineTag("0/lxd/1"), expect: true},
+		{tag: names.NewUnitTag("mysql/0"), expect: true},
+		{tag: names.NewServiceTag("mysql")},
+		{tag: names.NewUserTag("bob")},
+		{tag: names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")},
+		{tag: nil},
+	} {
+		c.Logf("test %d: %v", i, test.tag)
+		c.Check(names.IsAgentTag(test.tag), gc.Equals, test.expect)
+		if test.expect {
+			c.Check(test.tag, gc.Implements, new(names.AgentTag))
+		}
+	}
+}
+
+func (s *agentSuite) TestParseMachineOrUnitTag(c *gc.C) {
+	for i, test := range []struct {
+		tag    string
+		expect names.AgentTag
+		err    error
+	}{{
+		tag:    "machine-0",
+		expect: names.NewMachineTag("0"),
+	}, {
+		tag:    "machine-0-lxd-1",
+		expect: names.NewMachineTag("0/lxd/1"),
+	}, {
+		tag:    "unit-mysql-0",
+		expect: names.NewUnitTag("mysql/0"),
+	}, {
+		tag: "unit-mysql",
+		err: names.InvalidTagError("unit-mysql", names.UnitTagKind),
+	}, {
+		tag: "machine-01",
+		err: names.InvalidTagError("machine-01", names.MachineTagKind),
+	}, {
+		tag: "service-mysql",
+		err: names.InvalidTagError("service-mysql", "machine or unit"),
+	}, {
+		tag: "foo",
+		err: names.InvalidTagError("foo", "machine or unit"),
+	}, {
+		tag: "",
+		err: names.InvalidTagError("", "machine or unit"),
+	}} {
+		c.Logf("test %d: %q", i, test.tag)
+		tag, err := names.ParseMachineOrUnitTag(test.tag)
+		if test.err != nil {
+			c.Check(err, jc.DeepEquals, test.err)
+			c.Check(tag, gc.IsNil)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, test.expect)
+	}
+}
+
+func (s *agentSuite) TestParseMachineOrUnitTagError(c *gc.C) {
+	_, err := names.ParseMachineOrUnitTag("service-mysql")
+	c.Assert(err, gc.ErrorMatches, `"service-mysql" is not a valid machine or unit tag`)
+}
@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"sort"
+)
+
+// kindAliases maps deprecated tag kinds to the kinds
+// that replace them.
+var kindAliases = map[string]string{
+	EnvironTagKind: ModelTagKind,
+}
+
+// KindAliases returns a map from deprecated tag kinds
+// to the kinds that replace them.
+func KindAliases() map[string]string {
+	aliases := make(map[string]string, len(kindAliases))
+	for alias, kind := range kindAliases {
+		aliases[alias] = kind
+	}
+	return aliases
+}
+
+// discontinuedKinds holds tag kinds that are no longer
+// supported and have no replacement.
+var discontinuedKinds = map[string]bool{
+	"network": true,
+}
+
+// DiscontinuedKinds returns the tag kinds that are no
+// longer supported and have no replacement.
+func DiscontinuedKinds() []string {
+	kinds := make([]string, 0, len(discontinuedKinds))
+	for kind := range discontinuedKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// LegacyKinds returns a map from each tag kind that is no longer
+// current to the kind that replaces it, or to the empty string if
+// the kind is discontinued. Tools that upgrade stored tags can use it
+// to rewrite the kinds of the tags mechanically.
+func LegacyKinds() map[string]string {
+	kinds := KindAliases()
+	for kind := range discontinuedKinds {
+		kinds[kind] = ""
+	}
+	return kinds
+}
+
+// AliasPolicy determines how a Parser treats tags of deprecated kinds.
+type AliasPolicy int
+
+const (
+	// AcceptAliases accepts tags of deprecated kinds as they are.
+	// This is the behaviour of ParseTag.
+	AcceptAliases AliasPolicy = iota
+
+	// MapAliases accepts tags of deprecated kinds, returning the
+	// equivalent tag of the kind that replaces them.
+	MapAliases
+
+	// WarnAliases accepts tags of deprecated kinds as they are,
+	// reporting each one through the Parser's Warn function.
+	WarnAliases
+
+	// RejectAliases rejects tags of deprecated kinds.
+	RejectAliases
+)
+
+// DiscontinuedPolicy determines how a Parser treats
+// tags of discontinued kinds (see DiscontinuedKinds).
+type DiscontinuedPolicy int
+
+const (
+	// RejectDiscontinued rejects tags of discontinued kinds.
+	// This is the behaviour of ParseTag.
+	RejectDiscontinued DiscontinuedPolicy = iota
+
+	// WarnDiscontinued accepts tags of discontinued kinds as
+	// DiscontinuedTags, reporting each one through the Parser's
+	// Warn function.
+	WarnDiscontinued
+
+	// AcceptDiscontinued accepts tags of discontinued
+	// kinds as DiscontinuedTags.
+	AcceptDiscontinued
+)
+
+// DiscontinuedTag represents a tag of a discontinued kind. Its
+// id is not validated, as the rules for it are no longer known.
+type DiscontinuedTag struct {
+	kind string
+	id   string
+}
+
+func (t DiscontinuedTag) String() string { return t.kind + "-" + t.id }
+func (t DiscontinuedTag) Kind() string   { return t.kind }
+func (t DiscontinuedTag) Id() string     { return t.id }
+
+// Validate implements SelfValidator. As the rules for the ids of
+// discontinued kinds are no longer known, it only checks that the
+// kind is discontinued and that the id is not empty.
+func (t DiscontinuedTag) Validate() error {
+	if t.id == "" || !discontinuedKinds[t.kind] {
+		return invalidTagError(t.String(), t.kind)
+	}
+	return nil
+}
+
+// PathSegment returns the tag in a form safe for use
+// as a single path segment (see TagFromPathSegment).
+func (t DiscontinuedTag) PathSegment() string { return pathSegment(t) }
@@ -0,0 +1,42 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type aliasSuite struct{}
+
+var _ = gc.Suite(&aliasSuite{})
+
+func (s *aliasSuite) TestKindAliases(c *gc.C) {
+	aliases := names.KindAliases()
+	c.Assert(aliases, jc.DeepEquals, map[string]string{
+		names.EnvironTagKind: names.ModelTagKind,
+	})
+
+	// The returned map is a copy.
+	aliases["foo"] = "bar"
+	c.Assert(names.KindAliases(), gc.HasLen, 1)
+}
+
+func (s *aliasSuite) TestDiscontinuedKinds(c *gc.C) {
+	c.Assert(names.DiscontinuedKinds(), jc.DeepEquals, []string{"network"})
+}
+
+func (s *aliasSuite) TestLegacyKinds(c *gc.C) {
+	kinds := names.LegacyKinds()
+	c.Assert(kinds, jc.DeepEquals, map[string]string{
+		names.EnvironTagKind: names.ModelTagKind,
+		"network":            "",
+	})
+
+	// The returned map is a copy.
+	kinds["foo"] = "bar"
+	c.Assert(names.LegacyKinds(), gc.HasLen, 2)
+}
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package analyzer provides a vet-style analyzer that finds hard
+// coded tag strings, such as "unit-mysql-0", being compared or built
+// by concatenation, where the names constructors and parsers should
+// be used instead. It helps codebases move to typed tags.
+//
+// The analyzer may be run with the singlechecker or multichecker
+// packages of golang.org/x/tools, or plugged into go vet with
+// unitchecker.
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/juju/names"
+)
+
+// Analyzer reports string literals holding tags, or tag prefixes
+// such as "unit-", that are compared with other strings, passed to
+// strings.HasPrefix or strings.TrimPrefix, or concatenated.
+var Analyzer = &analysis.Analyzer{
+	Name:     "tagliteral",
+	Doc:      "report hard coded tag strings that are compared or concatenated instead of using names constructors and parsers",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			checkBinaryExpr(pass, n)
+		case *ast.CallExpr:
+			checkCallExpr(pass, n)
+		}
+	})
+	return nil, nil
+}
+
+func checkBinaryExpr(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	switch expr.Op {
+	case token.EQL, token.NEQ:
+		for _, operand := range []ast.Expr{expr.X, expr.Y} {
+			if s, ok := stringLiteral(operand); ok && isTag(s) {
+				pass.Reportf(operand.Pos(), "comparison with tag literal %q: parse the tag with names.ParseTag and compare tags instead", s)
+			}
+		}
+	case token.ADD:
+		for _, operand := range []ast.Expr{expr.X, expr.Y} {
+			s, ok := stringLiteral(operand)
+			if !ok {
+				continue
+			}
+			if kind, ok := tagPrefixKind(s); ok {
+				pass.Reportf(operand.Pos(), "%s tag built by concatenation: use the names constructor for %s tags instead", kind, kind)
+			}
+		}
+	}
+}
+
+// prefixFuncs holds the functions of the strings package that
+// are commonly used to pick apart tag strings by their prefix.
+var prefixFuncs = map[string]bool{
+	"HasPrefix":  true,
+	"TrimPrefix": true,
+	"CutPrefix":  true,
+}
+
+func checkCallExpr(pass *analysis.Pass, call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !prefixFuncs[sel.Sel.Name] || len(call.Args) != 2 {
+		return
+	}
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "strings" {
+		return
+	}
+	s, ok := stringLiteral(call.Args[1])
+	if !ok {
+		return
+	}
+	if kind, ok := tagPrefixKind(s); ok {
+		pass.Reportf(call.Args[1].Pos(), "%s tag prefix %q checked with strings.%s: use names.TagKind or names.Parse%sTag instead", kind, s, sel.Sel.Name, kindTitle(kind))
+	}
+}
+
+// stringLiteral returns the value of expr if
+// it is a string literal.
+func stringLiteral(expr ast.Expr) (string, bool) {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = paren.X
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// isTag returns whether s is a valid tag string.
+func isTag(s string) bool {
+	_, err := names.ParseTag(s)
+	return err == nil
+}
+
+// tagPrefixKind returns the kind of the tag that s is the start
+// of, or is, and whether there is one. Only complete tags and
+// kind prefixes, such as "unit-", are considered.
+func tagPrefixKind(s string) (string, bool) {
+	kind, err := names.TagKind(s)
+	if err != nil {
+		return "", false
+	}
+	if len(s) == len(kind)+1 || isTag(s) {
+		return kind, true
+	}
+	return "", false
+}
+
+// kindTitle returns the name used for the given kind in
+// the names of functions, as in ParseMachineTag.
+func kindTitle(kind string) string {
+	if title, ok := kindTitles[kind]; ok {
+		return title
+	}
+	return string(kind[0]-'a'+'A') + kind[1:]
+}
+
+var kindTitles = map[string]string{
+	names.EnvironTagKind:              "Environ",
+	names.FilesystemAttachmentTagKind: "FilesystemAttachment",
+	names.IPAddressTagKind:            "IPAddress",
+	names.VolumeAttachmentTagKind:     "VolumeAttachment",
+}
@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package analyzer_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	stdtesting "testing"
+
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names/analyzer"
+)
+
+func Test(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type analyzerSuite struct{}
+
+var _ = gc.Suite(&analyzerSuite{})
+
+var analyzerTests = []struct {
+	about string
+	src   string
+	diags []string
+}{{
+	about: "comparison with a tag",
+	src:   `func f(s string) bool { return s == "unit-mysql-0" }`,
+	diags: []string{
+		`comparison with tag literal "unit-mysql-0": parse the tag with names.ParseTag and compare tags instead`,
+	},
+}, {
+	about: "parenthesized comparison",
+	src:   `func f(s string) bool { return ("machine-0") != s }`,
+	diags: []string{
+		`comparison with tag literal "machine-0": parse the tag with names.ParseTag and compare tags instead`,
+	},
+}, {
+	about: "comparison with something that is not a tag",
+	src:   `func f(s string) bool { return s == "unit-" || s == "mysql/0" || s == "foo-bar" }`,
+}, {
+	about: "concatenation with a kind prefix",
+	src:   `func f(id string) string { return "machine-" + id }`,
+	diags: []string{
+		`machine tag built by concatenation: use the names constructor for machine tags instead`,
+	},
+}, {
+	about: "concatenation of a complete tag",
+	src:   `func f(id string) string { return "unit-mysql-0" + id }`,
+	diags: []string{
+		`unit tag built by concatenation: use the names constructor for unit tags instead`,
+	},
+}, {
+	about: "concatenation with other strings",
+	src:   `func f(id string) string { return "foo-" + id + "-unit" }`,
+}, {
+	about: "prefix checks",
+	src: `
+import "strings"
+
+func f(s string) (bool, string) {
+	return strings.HasPrefix(s, "unit-"), strings.TrimPrefix(s, "ipaddress-")
+}`,
+	diags: []string{
+		`unit tag prefix "unit-" checked with strings.HasPrefix: use names.TagKind or names.ParseUnitTag instead`,
+		`ipaddress tag prefix "ipaddress-" checked with strings.TrimPrefix: use names.TagKind or names.ParseIPAddressTag instead`,
+	},
+}, {
+	about: "prefix checks with other strings",
+	src: `
+import "strings"
+
+func f(s string) bool {
+	return strings.HasPrefix(s, "foo-") || strings.HasSuffix(s, "unit-") || strings.Contains(s, "unit-")
+}`,
+}, {
+	about: "prefix checks with functions not from strings",
+	src: `
+type strs struct{}
+
+func (strs) HasPrefix(s, prefix string) bool { return false }
+
+func f(s string) bool {
+	var strings strs
+	return strings.HasPrefix(s, "unit-")
+}`,
+}}
+
+func (s *analyzerSuite) TestAnalyzer(c *gc.C) {
+	for i, test := range analyzerTests {
+		c.Logf("test %d: %s", i, test.about)
+		diags, err := runAnalyzer("package p\n" + test.src)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(diags, jc.DeepEquals, test.diags)
+	}
+}
+
+// runAnalyzer runs the analyzer over the given source,
+// returning the messages of the diagnostics it reports.
+func runAnalyzer(src string) ([]string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	files := []*ast.File{f}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("p", fset, files, info)
+	if err != nil {
+		return nil, fmt.Errorf("cannot type check: %v", err)
+	}
+	var diags []string
+	pass := &analysis.Pass{
+		Analyzer:  analyzer.Analyzer,
+		Fset:      fset,
+		Files:     files,
+		Pkg:       pkg,
+		TypesInfo: info,
+		ResultOf: map[*analysis.Analyzer]interface{}{
+			inspect.Analyzer: inspector.New(files),
+		},
+		Report: func(d analysis.Diagnostic) {
+			diags = append(diags, d.Message)
+		},
+	}
+	if _, err := analyzer.Analyzer.Run(pass); err != nil {
+		return nil, err
+	}
+	return diags, nil
+}
+
+func (s *analyzerSuite) TestValidate(c *gc.C) {
+	c.Assert(analysis.Validate([]*analysis.Analyzer{analyzer.Analyzer}), jc.ErrorIsNil)
+}
@@ -0,0 +1,228 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	VolumeAttachmentTagKind     = "volumeattachment"
+	FilesystemAttachmentTagKind = "filesystemattachment"
+)
+
+// Attachment ids have the format "<machine-id>:<volume-id>" or
+// "<machine-id>:<filesystem-id>", e.g. "0/lxd/1:0/3". Attachment tags
+// join the forms the two ids take in their own tags in the same way,
+// e.g. "volumeattachment-0-lxd-1:0-3". Neither id may contain ":",
+// so the two are always separated unambiguously.
+
+// attachmentIdSeparator separates the machine and storage
+// parts of attachment ids and tags.
+const attachmentIdSeparator = ":"
+
+// splitAttachmentId splits an attachment id, or the suffix
+// of an attachment tag, into its machine and storage parts.
+func splitAttachmentId(id string) (machine, storage string, ok bool) {
+	i := strings.Index(id, attachmentIdSeparator)
+	if i == -1 {
+		return "", "", false
+	}
+	return id[:i], id[i+1:], true
+}
+
+// VolumeAttachmentTag represents the attachment
+// of a volume to a machine.
+type VolumeAttachmentTag struct {
+	machine MachineTag
+	volume  VolumeTag
+}
+
+func (t VolumeAttachmentTag) String() string {
+	return VolumeAttachmentTagKind + "-" + t.machine.suffix() + attachmentIdSeparator + t.volume.id
+}
+func (t VolumeAttachmentTag) Kind() string { return VolumeAttachmentTagKind }
+func (t VolumeAttachmentTag) Id() string {
+	return t.machine.Id() + attachmentIdSeparator + t.volume.Id()
+}
+func (t VolumeAttachmentTag) Validate() error     { return validateTag(t) }
+func (t VolumeAttachmentTag) PathSegment() string { return pathSegment(t) }
+
+// Machine returns the tag of the machine the volume is attached to.
+func (t VolumeAttachmentTag) Machine() MachineTag { return t.machine }
+
+// Volume returns the tag of the attached volume.
+func (t VolumeAttachmentTag) Volume() VolumeTag { return t.volume }
+
+// NewVolumeAttachmentTag returns the tag for the
+// attachment of the given volume to the given machine.
+func NewVolumeAttachmentTag(machine MachineTag, volume VolumeTag) VolumeAttachmentTag {
+	return VolumeAttachmentTag{machine: machine, volume: volume}
+}
+
+// ParseVolumeAttachmentTag parses a volume attachment tag string.
+func ParseVolumeAttachmentTag(s string) (VolumeAttachmentTag, error) {
+	tag, err := ParseTag(s)
+	if err != nil {
+		return VolumeAttachmentTag{}, err
+	}
+	vat, ok := tag.(VolumeAttachmentTag)
+	if !ok {
+		return VolumeAttachmentTag{}, invalidTagError(s, VolumeAttachmentTagKind)
+	}
+	return vat, nil
+}
+
+// IsValidVolumeAttachment returns whether id is a valid volume
+// attachment id.
+func IsValidVolumeAttachment(id string) bool {
+	machine, volume, ok := splitAttachmentId(id)
+	return ok && IsValidMachine(machine) && IsValidVolume(volume)
+}
+
+func tagFromVolumeAttachmentId(id string) (VolumeAttachmentTag, bool) {
+	if !IsValidVolumeAttachment(id) {
+		return VolumeAttachmentTag{}, false
+	}
+	machine, volume, _ := splitAttachmentId(id)
+	return NewVolumeAttachmentTag(NewMachineTag(machine), NewVolumeTag(volume)), true
+}
+
+func volumeAttachmentTagSuffixToId(s string) string {
+	machine, volume, ok := splitAttachmentId(s)
+	if !ok {
+		return s
+	}
+	return machineTagSuffixToId(machine) + attachmentIdSeparator + volumeTagSuffixToId(volume)
+}
+
+// FilesystemAttachmentTag represents the attachment
+// of a filesystem to a machine.
+type FilesystemAttachmentTag struct {
+	machine    MachineTag
+	filesystem FilesystemTag
+}
+
+func (t FilesystemAttachmentTag) String() string {
+	return FilesystemAttachmentTagKind + "-" + t.machine.suffix() + attachmentIdSeparator + t.filesystem.id
+}
+func (t FilesystemAttachmentTag) Kind() string { return FilesystemAttachmentTagKind }
+func (t FilesystemAttachmentTag) Id() string {
+	return t.machine.Id() + attachmentIdSeparator + t.filesystem.Id()
+}
+func (t FilesystemAttachmentTag) Validate() error     { return validateTag(t) }
+func (t FilesystemAttachmentTag) PathSegment() string { return pathSegment(t) }
+
+// Machine returns the tag of the machine the filesystem is attached to.
+func (t FilesystemAttachmentTag) Machine() MachineTag { return t.machine }
+
+// Filesystem returns the tag of the attached filesystem.
+func (t FilesystemAttachmentTag) Filesystem() FilesystemTag { return t.filesystem }
+
+// NewFilesystemAttachmentTag returns the tag for the
+// attachment of the given filesystem to the given machine.
+func NewFilesystemAttachmentTag(machine MachineTag, filesystem FilesystemTag) FilesystemAttachmentTag {
+	return FilesystemAttachmentTag{machine: machine, filesystem: filesystem}
+}
+
+// ParseFilesystemAttachmentTag parses a filesystem attachment tag string.
+func ParseFilesystemAttachmentTag(s string) (FilesystemAttachmentTag, error) {
+	tag, err := ParseTag(s)
+	if err != nil {
+		return FilesystemAttachmentTag{}, err
+	}
+	fat, ok := tag.(FilesystemAttachmentTag)
+	if !ok {
+		return FilesystemAttachmentTag{}, invalidTagError(s, FilesystemAttachmentTagKind)
+	}
+	return fat, nil
+}
+
+// IsValidFilesystemAttachment returns whether id is a valid
+// filesystem attachment id.
+func IsValidFilesystemAttachment(id string) bool {
+	machine, filesystem, ok := splitAttachmentId(id)
+	return ok && IsValidMachine(machine) && IsValidFilesystem(filesystem)
+}
+
+func tagFromFilesystemAttachmentId(id string) (FilesystemAttachmentTag, bool) {
+	if !IsValidFilesystemAttachment(id) {
+		return FilesystemAttachmentTag{}, false
+	}
+	machine, filesystem, _ := splitAttachmentId(id)
+	return NewFilesystemAttachmentTag(NewMachineTag(machine), NewFilesystemTag(filesystem)), true
+}
+
+func filesystemAttachmentTagSuffixToId(s string) string {
+	machine, filesystem, ok := splitAttachmentId(s)
+	if !ok {
+		return s
+	}
+	return machineTagSuffixToId(machine) + attachmentIdSeparator + filesystemTagSuffixToId(filesystem)
+}
+
+// ParseVolumeAttachmentId parses the id of the attachment of a volume
+// to a machine or unit, of the form "<host>:<volume-id>" where host is
+// a machine id or unit name, returning the tags of the host and of the
+// volume. Volume attachment tag ids are of this form.
+func ParseVolumeAttachmentId(id string) (AgentTag, VolumeTag, error) {
+	host, volume, err := parseAttachmentId(id, attachmentIdSeparator, "volume attachment")
+	if err != nil {
+		return nil, VolumeTag{}, err
+	}
+	if !IsValidVolume(volume) {
+		return nil, VolumeTag{}, fmt.Errorf("%q is not a valid volume attachment id: invalid volume id %q", id, volume)
+	}
+	return host, NewVolumeTag(volume), nil
+}
+
+// ParseFilesystemAttachmentId is like ParseVolumeAttachmentId,
+// but for the attachments of filesystems.
+func ParseFilesystemAttachmentId(id string) (AgentTag, FilesystemTag, error) {
+	host, filesystem, err := parseAttachmentId(id, attachmentIdSeparator, "filesystem attachment")
+	if err != nil {
+		return nil, FilesystemTag{}, err
+	}
+	if !IsValidFilesystem(filesystem) {
+		return nil, FilesystemTag{}, fmt.Errorf("%q is not a valid filesystem attachment id: invalid filesystem id %q", id, filesystem)
+	}
+	return host, NewFilesystemTag(filesystem), nil
+}
+
+// ParseStorageAttachmentId parses the id of the attachment of a
+// storage instance to a unit, of the form "<unit-name>#<storage-id>",
+// returning the tags of the unit and of the storage instance.
+func ParseStorageAttachmentId(id string) (UnitTag, StorageTag, error) {
+	host, storage, err := parseAttachmentId(id, "#", "storage attachment")
+	if err != nil {
+		return UnitTag{}, StorageTag{}, err
+	}
+	unit, ok := host.(UnitTag)
+	if !ok {
+		return UnitTag{}, StorageTag{}, fmt.Errorf("%q is not a valid storage attachment id: invalid unit name %q", id, host.Id())
+	}
+	if !IsValidStorage(storage) {
+		return UnitTag{}, StorageTag{}, fmt.Errorf("%q is not a valid storage attachment id: invalid storage id %q", id, storage)
+	}
+	return unit, NewStorageTag(storage), nil
+}
+
+// parseAttachmentId splits the given attachment id at sep, returning
+// the tag of the machine or unit before it and the rest of the id.
+// The description of the id is used in errors.
+func parseAttachmentId(id, sep, what string) (AgentTag, string, error) {
+	i := strings.Index(id, sep)
+	if i == -1 {
+		return nil, "", fmt.Errorf("%q is not a valid %s id", id, what)
+	}
+	host := id[:i]
+	switch {
+	case IsValidMachine(host):
+		return NewMachineTag(host), id[i+1:], nil
+	case IsValidUnit(host):
+		return NewUnitTag(host), id[i+1:], nil
+	}
+	return nil, "", fmt.Errorf("%q is not a valid %s id: invalid machine id or unit name %q", id, what, host)
+}
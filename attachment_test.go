@@ -0,0 +1,168 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type attachmentSuite struct{}
+
+var _ = gc.Suite(&attachmentSuite{})
+
+func (s *attachmentSuite) TestVolumeAttachmentTag(c *gc.C) {
+	machine := names.NewMachineTag("0/lxd/1")
+	volume := names.NewVolumeTag("0/lxd/1/3")
+	tag := names.NewVolumeAttachmentTag(machine, volume)
+	c.Assert(tag.Kind(), gc.Equals, names.VolumeAttachmentTagKind)
+	c.Assert(tag.Id(), gc.Equals, "0/lxd/1:0/lxd/1/3")
+	c.Assert(tag.String(), gc.Equals, "volumeattachment-0-lxd-1:0-lxd-1-3")
+	c.Assert(tag.Machine(), gc.Equals, machine)
+	c.Assert(tag.Volume(), gc.Equals, volume)
+
+	parsed, err := names.ParseVolumeAttachmentTag(tag.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, gc.Equals, tag)
+}
+
+func (s *attachmentSuite) TestFilesystemAttachmentTag(c *gc.C) {
+	machine := names.NewMachineTag("2")
+	filesystem := names.NewFilesystemTag("rabbitmq-server/0/1")
+	tag := names.NewFilesystemAttachmentTag(machine, filesystem)
+	c.Assert(tag.Kind(), gc.Equals, names.FilesystemAttachmentTagKind)
+	c.Assert(tag.Id(), gc.Equals, "2:rabbitmq-server/0/1")
+	c.Assert(tag.String(), gc.Equals, "filesystemattachment-2:rabbitmq-server-0-1")
+	c.Assert(tag.Machine(), gc.Equals, machine)
+	c.Assert(tag.Filesystem(), gc.Equals, filesystem)
+
+	parsed, err := names.ParseFilesystemAttachmentTag(tag.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, gc.Equals, tag)
+}
+
+func (s *attachmentSuite) TestIsValidAttachment(c *gc.C) {
+	for i, test := range []struct {
+		id    string
+		valid bool
+	}{
+		{"0:1", true},
+		{"0/lxd/1:0/lxd/1/3", true},
+		{"0:mysql/0/1", true},
+		{"0", false},
+		{"0:", false},
+		{":1", false},
+		{"0:a", false},
+		{"0:1:2", false},
+		{"mysql/0:1", false},
+		{"0/lxd:1", false},
+	} {
+		c.Logf("test %d: %q", i, test.id)
+		c.Check(names.IsValidVolumeAttachment(test.id), gc.Equals, test.valid)
+		c.Check(names.IsValidFilesystemAttachment(test.id), gc.Equals, test.valid)
+	}
+}
+
+func (s *attachmentSuite) TestParseAttachmentTagErrors(c *gc.C) {
+	_, err := names.ParseVolumeAttachmentTag("filesystemattachment-0:1")
+	c.Check(err, jc.DeepEquals, names.InvalidTagError("filesystemattachment-0:1", names.VolumeAttachmentTagKind))
+	_, err = names.ParseVolumeAttachmentTag("volumeattachment-0")
+	c.Check(err, jc.DeepEquals, names.InvalidTagError("volumeattachment-0", names.VolumeAttachmentTagKind))
+	_, err = names.ParseFilesystemAttachmentTag("volumeattachment-0:1")
+	c.Check(err, jc.DeepEquals, names.InvalidTagError("volumeattachment-0:1", names.FilesystemAttachmentTagKind))
+	_, err = names.ParseFilesystemAttachmentTag("foo")
+	c.Check(err, jc.DeepEquals, names.InvalidTagError("foo", ""))
+}
+
+func (s *attachmentSuite) TestParseVolumeAttachmentId(c *gc.C) {
+	for i, test := range []struct {
+		id     string
+		host   names.AgentTag
+		volume names.VolumeTag
+		err    string
+	}{{
+		id:     "0:0/1",
+		host:   names.NewMachineTag("0"),
+		volume: names.NewVolumeTag("0/1"),
+	}, {
+		id:     "0/lxd/1:2",
+		host:   names.NewMachineTag("0/lxd/1"),
+		volume: names.NewVolumeTag("2"),
+	}, {
+		id:     "mysql/0:mysql/0/1",
+		host:   names.NewUnitTag("mysql/0"),
+		volume: names.NewVolumeTag("mysql/0/1"),
+	}, {
+		id:  "0",
+		err: `"0" is not a valid volume attachment id`,
+	}, {
+		id:  "mysql:0",
+		err: `"mysql:0" is not a valid volume attachment id: invalid machine id or unit name "mysql"`,
+	}, {
+		id:  "0:a",
+		err: `"0:a" is not a valid volume attachment id: invalid volume id "a"`,
+	}} {
+		c.Logf("test %d: %q", i, test.id)
+		host, volume, err := names.ParseVolumeAttachmentId(test.id)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(host, gc.Equals, test.host)
+		c.Check(volume, gc.Equals, test.volume)
+	}
+}
+
+func (s *attachmentSuite) TestParseVolumeAttachmentIdFromTag(c *gc.C) {
+	tag := names.NewVolumeAttachmentTag(names.NewMachineTag("3"), names.NewVolumeTag("3/0"))
+	host, volume, err := names.ParseVolumeAttachmentId(tag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(host, gc.Equals, tag.Machine())
+	c.Assert(volume, gc.Equals, tag.Volume())
+}
+
+func (s *attachmentSuite) TestParseFilesystemAttachmentId(c *gc.C) {
+	host, filesystem, err := names.ParseFilesystemAttachmentId("mysql/0:0/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(host, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Assert(filesystem, gc.Equals, names.NewFilesystemTag("0/1"))
+
+	_, _, err = names.ParseFilesystemAttachmentId("0:0/")
+	c.Assert(err, gc.ErrorMatches, `"0:0/" is not a valid filesystem attachment id: invalid filesystem id "0/"`)
+}
+
+func (s *attachmentSuite) TestParseStorageAttachmentId(c *gc.C) {
+	for i, test := range []struct {
+		id      string
+		unit    names.UnitTag
+		storage names.StorageTag
+		err     string
+	}{{
+		id:      "mysql/0#data/1",
+		unit:    names.NewUnitTag("mysql/0"),
+		storage: names.NewStorageTag("data/1"),
+	}, {
+		id:  "mysql/0:data/1",
+		err: `"mysql/0:data/1" is not a valid storage attachment id`,
+	}, {
+		id:  "0#data/1",
+		err: `"0#data/1" is not a valid storage attachment id: invalid unit name "0"`,
+	}, {
+		id:  "mysql/0#data",
+		err: `"mysql/0#data" is not a valid storage attachment id: invalid storage id "data"`,
+	}} {
+		c.Logf("test %d: %q", i, test.id)
+		unit, storage, err := names.ParseStorageAttachmentId(test.id)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(unit, gc.Equals, test.unit)
+		c.Check(storage, gc.Equals, test.storage)
+	}
+}
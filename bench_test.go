@@ -0,0 +1,183 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"strings"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+// benchTags holds a typical tag of each kind, along with the most
+// allocations that parsing it and formatting it are allowed to take.
+// Raise a limit only when the extra cost is understood and accepted.
+var benchTags = []struct {
+	kind         string
+	tag          string
+	parseAllocs  float64
+	stringAllocs float64
+}{
+	{names.ActionTagKind, "action-f47ac10b-58cc-4372-a567-0e02b2c3d479", 1, 8},
+	{names.CharmTagKind, "charm-cs:trusty/mysql-42", 7, 1},
+	{names.EnvironTagKind, "environment-f47ac10b-58cc-4372-a567-0e02b2c3d479", 1, 1},
+	{names.FilesystemTagKind, "filesystem-0-lxc-0-1", 3, 1},
+	{names.IPAddressTagKind, "ipaddress-f47ac10b-58cc-4372-a567-0e02b2c3d479", 3, 8},
+	{names.MachineTagKind, "machine-0-lxc-1", 4, 0},
+	{names.ModelTagKind, "model-f47ac10b-58cc-4372-a567-0e02b2c3d479", 1, 1},
+	{names.PayloadTagKind, "payload-f47ac10b-58cc-4372-a567-0e02b2c3d479", 1, 2},
+	{names.RelationTagKind, "relation-wordpress.db#mysql.server", 5, 1},
+	{names.ServiceTagKind, "service-wordpress", 1, 1},
+	{names.SpaceTagKind, "space-dmz", 1, 1},
+	{names.StorageTagKind, "storage-data-0", 3, 1},
+	{names.SubnetTagKind, "subnet-10.0.0.0/24", 13, 1},
+	{names.UnitTagKind, "unit-rabbitmq-server-123", 3, 0},
+	{names.UserTagKind, "user-bob@local", 5, 1},
+	{names.VolumeTagKind, "volume-0-1", 3, 1},
+}
+
+// benchValidators holds the validator for each kind, along with a
+// typical valid id and the most allocations that validating it is
+// allowed to take.
+var benchValidators = []struct {
+	kind    string
+	isValid func(string) bool
+	id      string
+	allocs  float64
+}{
+	{names.ActionTagKind, names.IsValidAction, "f47ac10b-58cc-4372-a567-0e02b2c3d479", 0},
+	{names.CharmTagKind, names.IsValidCharm, "cs:trusty/mysql-42", 3},
+	{names.EnvironTagKind, names.IsValidEnvironment, "f47ac10b-58cc-4372-a567-0e02b2c3d479", 0},
+	{names.FilesystemTagKind, names.IsValidFilesystem, "0/lxc/0/1", 0},
+	{names.IPAddressTagKind, names.IsValidIPAddress, "f47ac10b-58cc-4372-a567-0e02b2c3d479", 1},
+	{names.MachineTagKind, names.IsValidMachine, "0/lxc/1", 0},
+	{names.ModelTagKind, names.IsValidModel, "f47ac10b-58cc-4372-a567-0e02b2c3d479", 0},
+	{names.PayloadTagKind, names.IsValidPayload, "f47ac10b-58cc-4372-a567-0e02b2c3d479", 0},
+	{names.RelationTagKind, names.IsValidRelation, "wordpress:db mysql:server", 0},
+	{names.ServiceTagKind, names.IsValidService, "wordpress", 0},
+	{names.SpaceTagKind, names.IsValidSpace, "dmz", 0},
+	{names.StorageTagKind, names.IsValidStorage, "data/0", 0},
+	{names.SubnetTagKind, names.IsValidSubnet, "10.0.0.0/24", 6},
+	{names.UnitTagKind, names.IsValidUnit, "rabbitmq-server/123", 0},
+	{names.UserTagKind, names.IsValidUser, "bob@local", 2},
+	{names.VolumeTagKind, names.IsValidVolume, "0/1", 0},
+}
+
+type benchSuite struct{}
+
+var _ = gc.Suite(&benchSuite{})
+
+func (s *benchSuite) TestParseTagAllocs(c *gc.C) {
+	for i, test := range benchTags {
+		c.Logf("test %d: %q", i, test.tag)
+		_, err := names.ParseTag(test.tag)
+		c.Assert(err, jc.ErrorIsNil)
+		allocs := testing.AllocsPerRun(100, func() {
+			names.ParseTag(test.tag)
+		})
+		c.Check(allocs <= test.parseAllocs, jc.IsTrue,
+			gc.Commentf("%s: %v allocations, want at most %v", test.kind, allocs, test.parseAllocs))
+	}
+}
+
+func (s *benchSuite) TestStringAllocs(c *gc.C) {
+	for i, test := range benchTags {
+		c.Logf("test %d: %q", i, test.tag)
+		tag, err := names.ParseTag(test.tag)
+		c.Assert(err, jc.ErrorIsNil)
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = tag.String()
+		})
+		c.Check(allocs <= test.stringAllocs, jc.IsTrue,
+			gc.Commentf("%s: %v allocations, want at most %v", test.kind, allocs, test.stringAllocs))
+	}
+}
+
+func (s *benchSuite) TestIsValidAllocs(c *gc.C) {
+	for i, test := range benchValidators {
+		c.Logf("test %d: %s %q", i, test.kind, test.id)
+		c.Assert(test.isValid(test.id), jc.IsTrue)
+		allocs := testing.AllocsPerRun(100, func() {
+			test.isValid(test.id)
+		})
+		c.Check(allocs <= test.allocs, jc.IsTrue,
+			gc.Commentf("%s: %v allocations, want at most %v", test.kind, allocs, test.allocs))
+	}
+}
+
+func BenchmarkParseTag(b *testing.B) {
+	for _, test := range benchTags {
+		test := test
+		b.Run(test.kind, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				names.ParseTag(test.tag)
+			}
+		})
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	for _, test := range benchValidators {
+		test := test
+		b.Run(test.kind, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				test.isValid(test.id)
+			}
+		})
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	for _, test := range benchTags {
+		tag, err := names.ParseTag(test.tag)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(test.kind, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = tag.String()
+			}
+		})
+	}
+}
+
+// BenchmarkParseTagList measures parsing a large list holding
+// tags of every kind.
+func BenchmarkParseTagList(b *testing.B) {
+	var tags []string
+	for i := 0; i < 100; i++ {
+		for _, test := range benchTags {
+			tags = append(tags, test.tag)
+		}
+	}
+	list := strings.Join(tags, ",")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := names.ParseTagList(list); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnitIdFromTagString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		names.UnitIdFromTagString("unit-mysql-0")
+	}
+}
+
+func BenchmarkParseUnitTagId(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tag, _ := names.ParseUnitTag("unit-mysql-0")
+		_ = tag.Id()
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// bulkChunkSize holds the number of tags each worker
+// validates at a time in ValidateTagsParallel.
+const bulkChunkSize = 1024
+
+// ValidateTagsParallel validates the given tag strings using the
+// given number of goroutines, or GOMAXPROCS goroutines if workers is
+// not positive. It returns a slice holding, for each tag, nil if the
+// tag is valid, or the error ParseTag returns for it if not.
+//
+// If the context is done before all the tags are validated, it
+// returns the context's error instead.
+func ValidateTagsParallel(ctx context.Context, tags []string, workers int) ([]error, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunks := (len(tags) + bulkChunkSize - 1) / bulkChunkSize
+	if workers > chunks {
+		workers = chunks
+	}
+	errs := make([]error, len(tags))
+	var next int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				chunk := int(atomic.AddInt64(&next, 1) - 1)
+				if chunk >= chunks {
+					return
+				}
+				end := (chunk + 1) * bulkChunkSize
+				if end > len(tags) {
+					end = len(tags)
+				}
+				for i := chunk * bulkChunkSize; i < end; i++ {
+					if !IsValidTag(tags[i]) {
+						_, errs[i] = ParseTag(tags[i])
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
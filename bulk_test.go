@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type bulkSuite struct{}
+
+var _ = gc.Suite(&bulkSuite{})
+
+// bulkTags returns n tag strings, every third of which is invalid.
+func bulkTags(n int) []string {
+	tags := make([]string, n)
+	for i := range tags {
+		switch i % 3 {
+		case 0:
+			tags[i] = fmt.Sprintf("machine-%d", i)
+		case 1:
+			tags[i] = fmt.Sprintf("unit-mysql-%d", i)
+		case 2:
+			tags[i] = fmt.Sprintf("unit-mysql-0%d", i)
+		}
+	}
+	return tags
+}
+
+func (s *bulkSuite) TestValidateTagsParallel(c *gc.C) {
+	for i, test := range []struct {
+		n       int
+		workers int
+	}{
+		{n: 0, workers: 4},
+		{n: 10, workers: 4},
+		{n: 5000, workers: 1},
+		{n: 5000, workers: 3},
+		{n: 5000, workers: 0},
+		{n: 5000, workers: -1},
+		{n: 5000, workers: 100},
+	} {
+		c.Logf("test %d: %d tags, %d workers", i, test.n, test.workers)
+		tags := bulkTags(test.n)
+		errs, err := names.ValidateTagsParallel(context.Background(), tags, test.workers)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(errs, gc.HasLen, len(tags))
+		for j, tag := range tags {
+			_, expect := names.ParseTag(tag)
+			c.Assert(errs[j], jc.DeepEquals, expect, gc.Commentf("tag %d: %q", j, tag))
+		}
+	}
+}
+
+func (s *bulkSuite) TestValidateTagsParallelCancelled(c *gc.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs, err := names.ValidateTagsParallel(ctx, bulkTags(5000), 4)
+	c.Assert(err, gc.Equals, context.Canceled)
+	c.Assert(errs, gc.IsNil)
+}
+
+func BenchmarkValidateTagsParallel(b *testing.B) {
+	tags := bulkTags(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := names.ValidateTagsParallel(context.Background(), tags, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateTagsSerial(b *testing.B) {
+	tags := bulkTags(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, tag := range tags {
+			names.ParseTag(tag)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CachingParser parses tag strings exactly as ParseTag does, but
+// remembers the results for the most recently parsed strings, so
+// that parsing the same strings over and over is cheap. It is safe
+// for concurrent use.
+type CachingParser struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// recent holds *parseResult values, most recently used first.
+	recent *list.List
+}
+
+// parseResult holds the result of parsing a tag string.
+type parseResult struct {
+	s   string
+	tag Tag
+	err error
+}
+
+// NewCachingParser returns a parser that remembers the results
+// for up to size tag strings. It panics if size is not positive.
+func NewCachingParser(size int) *CachingParser {
+	if size <= 0 {
+		panic("cache size must be positive")
+	}
+	return &CachingParser{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		recent:  list.New(),
+	}
+}
+
+// ParseTag parses a string representation into a Tag.
+func (p *CachingParser) ParseTag(s string) (Tag, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.entries[s]; ok {
+		p.recent.MoveToFront(e)
+		r := e.Value.(*parseResult)
+		return r.tag, r.err
+	}
+	tag, err := ParseTag(s)
+	if p.recent.Len() >= p.size {
+		oldest := p.recent.Back()
+		p.recent.Remove(oldest)
+		delete(p.entries, oldest.Value.(*parseResult).s)
+	}
+	p.entries[s] = p.recent.PushFront(&parseResult{s: s, tag: tag, err: err})
+	return tag, err
+}
+
+// Len returns the number of tag strings whose results are remembered.
+func (p *CachingParser) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.recent.Len()
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type cacheSuite struct{}
+
+var _ = gc.Suite(&cacheSuite{})
+
+func (s *cacheSuite) TestMatchesParseTag(c *gc.C) {
+	p := names.NewCachingParser(4)
+	for i, test := range parseTagTests {
+		c.Logf("test %d: %q", i, test.tag)
+		expectTag, expectErr := names.ParseTag(test.tag)
+		// Parse twice, to check both fresh and remembered results.
+		for j := 0; j < 2; j++ {
+			tag, err := p.ParseTag(test.tag)
+			c.Check(tag, gc.Equals, expectTag)
+			c.Check(err, jc.DeepEquals, expectErr)
+		}
+	}
+	c.Check(p.Len(), gc.Equals, 4)
+}
+
+func (s *cacheSuite) TestEvictsLeastRecentlyUsed(c *gc.C) {
+	p := names.NewCachingParser(2)
+	p.ParseTag("machine-0")
+	p.ParseTag("machine-1")
+	// Using machine-0 makes machine-1 the least recently used.
+	p.ParseTag("machine-0")
+	p.ParseTag("machine-2")
+	c.Check(p.Len(), gc.Equals, 2)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		p.ParseTag("machine-0")
+		p.ParseTag("machine-2")
+	})
+	c.Check(allocs, gc.Equals, 0.0)
+}
+
+func (s *cacheSuite) TestConcurrentUse(c *gc.C) {
+	p := names.NewCachingParser(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := fmt.Sprintf("%d", (i+j)%20)
+				tag, err := p.ParseTag("machine-" + id)
+				c.Check(err, jc.ErrorIsNil)
+				c.Check(tag, gc.Equals, names.NewMachineTag(id))
+			}
+		}(i)
+	}
+	wg.Wait()
+	c.Check(p.Len(), gc.Equals, 10)
+}
+
+func (s *cacheSuite) TestInvalidSize(c *gc.C) {
+	c.Check(func() { names.NewCachingParser(0) }, gc.PanicMatches, "cache size must be positive")
+}
+
+func BenchmarkCachingParser(b *testing.B) {
+	p := names.NewCachingParser(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.ParseTag("unit-rabbitmq-server-123")
+	}
+}
+
+func BenchmarkParseTagUnit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		names.ParseTag("unit-rabbitmq-server-123")
+	}
+}
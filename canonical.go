@@ -0,0 +1,39 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"strings"
+)
+
+// Canonicalize returns the tag in its canonical form, replacing tags
+// of renamed kinds with their current equivalent. For example, an
+// EnvironTag becomes the ModelTag with the same UUID. Other tags are
+// returned unchanged.
+func Canonicalize(tag Tag) Tag {
+	switch tag := tag.(type) {
+	case EnvironTag:
+		return tag.ToModelTag()
+	}
+	return tag
+}
+
+// CanonicalTagString parses the given tag string and returns the
+// string form of its canonical tag, so that strings naming the same
+// entity compare equal. Kinds are resolved as by Canonicalize and
+// UUIDs are in lower case. User names and domains are in lower case,
+// and local users are always qualified with the local domain, as by
+// UserTag.Canonical, matching UserTag.EqualsIgnoreCase.
+func CanonicalTagString(s string) (string, error) {
+	tag, err := ParseTag(s)
+	if err != nil {
+		return "", err
+	}
+	switch tag := Canonicalize(tag).(type) {
+	case UserTag:
+		return UserTagKind + "-" + strings.ToLower(tag.Canonical()), nil
+	default:
+		return tag.String(), nil
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type canonicalSuite struct{}
+
+var _ = gc.Suite(&canonicalSuite{})
+
+const canonicalUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func (s *canonicalSuite) TestCanonicalize(c *gc.C) {
+	for i, test := range []struct {
+		tag    names.Tag
+		expect names.Tag
+	}{
+		{tag: nil, expect: nil},
+		{tag: names.NewEnvironTag(canonicalUUID), expect: names.NewModelTag(canonicalUUID)},
+		{tag: names.NewModelTag(canonicalUUID), expect: names.NewModelTag(canonicalUUID)},
+		{tag: names.NewMachineTag("0"), expect: names.NewMachineTag("0")},
+	} {
+		c.Logf("test %d: %v", i, test.tag)
+		c.Check(names.Canonicalize(test.tag), gc.Equals, test.expect)
+	}
+}
+
+func (s *canonicalSuite) TestCanonicalTagString(c *gc.C) {
+	for i, test := range []struct {
+		tag    string
+		expect string
+		err    string
+	}{
+		{tag: "environment-" + canonicalUUID, expect: "model-" + canonicalUUID},
+		{tag: "model-" + canonicalUUID, expect: "model-" + canonicalUUID},
+		{tag: "unit-mysql-0", expect: "unit-mysql-0"},
+		{tag: "environment-F47AC10B-58CC-4372-A567-0E02B2C3D479", expect: "model-" + canonicalUUID},
+		{tag: "model-F47AC10B-58CC-4372-A567-0E02B2C3D479", expect: "model-" + canonicalUUID},
+		{tag: "user-bob", expect: "user-bob@local"},
+		{tag: "user-bob@local", expect: "user-bob@local"},
+		{tag: "user-bob@example.com", expect: "user-bob@example.com"},
+		{tag: "user-Bob", expect: "user-bob@local"},
+		{tag: "user-BOB@Local", expect: "user-bob@local"},
+		{tag: "user-Bob@Example.COM", expect: "user-bob@example.com"},
+		{tag: "environment-foo", err: `"environment-foo" is not a valid environment tag`},
+		{tag: "network-foo", err: `"network-foo" is not a valid tag`},
+	} {
+		c.Logf("test %d: %q", i, test.tag)
+		s, err := names.CanonicalTagString(test.tag)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(s, gc.Equals, test.expect)
+	}
+}
+
+func (s *canonicalSuite) TestCanonicalTagStringIdempotent(c *gc.C) {
+	for _, entry := range namestest.Corpus() {
+		if !entry.Valid {
+			continue
+		}
+		canonical, err := names.CanonicalTagString(entry.Tag)
+		c.Assert(err, jc.ErrorIsNil)
+		again, err := names.CanonicalTagString(canonical)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(again, gc.Equals, canonical, gc.Commentf("tag %q", entry.Tag))
+	}
+}
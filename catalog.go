@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+)
+
+// A Catalog provides translations of the English messages used to
+// render tags in human-readable form, by LocalizedReadableString and
+// LocalizedDescribe.
+//
+// Messages are fmt format strings such as "machine %s". A translation
+// must consume the same arguments, in the same order, as the message
+// it replaces.
+type Catalog interface {
+	// Lookup returns the translation of msg, and whether
+	// one was found.
+	Lookup(msg string) (string, bool)
+}
+
+// MapCatalog is a Catalog backed by a map from English
+// messages to their translations.
+type MapCatalog map[string]string
+
+// Lookup implements Catalog.
+func (c MapCatalog) Lookup(msg string) (string, bool) {
+	t, ok := c[msg]
+	return t, ok
+}
+
+// LocalizedReadableString returns a human-readable string from the tag
+// passed in, translated through the given catalog. Messages missing
+// from the catalog, or all messages if catalog is nil, are rendered
+// in English, so LocalizedReadableString(nil, tag) == ReadableString(tag).
+func LocalizedReadableString(catalog Catalog, tag Tag) string {
+	switch tag := tag.(type) {
+	case nil:
+		return ""
+	case MachineTag:
+		return readableMachine(catalog, tag)
+	case UnitTag:
+		if tag.Id() != "" {
+			return translate(catalog, "unit %d of %s", tag.Number(), tag.Service().Id())
+		}
+	}
+	return translate(catalog, tag.Kind()+" %s", displayId(tag))
+}
+
+// readableMachine returns the human-readable form of the machine
+// tag, describing containers in terms of their hosts so that,
+// for example, machine 0/lxd/3 reads "container 3 (lxd) on machine 0".
+func readableMachine(catalog Catalog, tag MachineTag) string {
+	parent, ok := tag.Parent()
+	if !ok {
+		return translate(catalog, "machine %s", tag.Id())
+	}
+	return translate(catalog, "container %s (%s) on %s", tag.ChildId(), tag.ContainerType(), readableMachine(catalog, parent))
+}
+
+// translate looks msg up in catalog and formats the result with the
+// given arguments, falling back to msg itself when there is no
+// translation.
+func translate(catalog Catalog, msg string, args ...interface{}) string {
+	if catalog != nil {
+		if t, ok := catalog.Lookup(msg); ok {
+			msg = t
+		}
+	}
+	return fmt.Sprintf(msg, args...)
+}
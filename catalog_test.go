@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type catalogSuite struct{}
+
+var _ = gc.Suite(&catalogSuite{})
+
+var testCatalog = names.MapCatalog{
+	"machine %s":              "machine %s (fr)",
+	"container %s (%s) on %s": "conteneur %s (%s) sur %s",
+	"unit %d of %s":           "unité %d de %s",
+}
+
+func (*catalogSuite) TestLocalizedReadableString(c *gc.C) {
+	for i, test := range []struct {
+		catalog names.Catalog
+		tag     names.Tag
+		result  string
+	}{{
+		catalog: testCatalog,
+		tag:     nil,
+		result:  "",
+	}, {
+		catalog: testCatalog,
+		tag:     names.NewUnitTag("wordpress/2"),
+		result:  "unité 2 de wordpress",
+	}, {
+		catalog: testCatalog,
+		tag:     names.NewMachineTag("0"),
+		result:  "machine 0 (fr)",
+	}, {
+		catalog: testCatalog,
+		tag:     names.NewMachineTag("0/lxd/1"),
+		result:  "conteneur 1 (lxd) sur machine 0 (fr)",
+	}, {
+		catalog: testCatalog,
+		tag:     names.NewServiceTag("mysql"),
+		result:  "service mysql",
+	}, {
+		catalog: nil,
+		tag:     names.NewUnitTag("wordpress/2"),
+		result:  "unit 2 of wordpress",
+	}, {
+		catalog: nil,
+		tag:     names.UnitTag{},
+		result:  "unit ",
+	}} {
+		c.Logf("test %d: expected result %q", i, test.result)
+		c.Check(names.LocalizedReadableString(test.catalog, test.tag), gc.Equals, test.result)
+	}
+}
+
+func (*catalogSuite) TestReadableStringUsesNoCatalog(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	c.Assert(names.ReadableString(tag), gc.Equals, names.LocalizedReadableString(nil, tag))
+}
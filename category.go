@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+// Category classifies tag kinds into broad groups, so that code
+// handling many kinds, such as permission checks, watchers and
+// printers, can act on the group rather than list its kinds.
+type Category string
+
+const (
+	// UnknownCategory is the category of unknown kinds.
+	UnknownCategory Category = ""
+
+	// AgentCategory holds the kinds of entities that run
+	// agents: machines and units.
+	AgentCategory Category = "agent"
+
+	// StorageCategory holds the kinds of storage entities:
+	// storage instances, volumes and filesystems.
+	StorageCategory Category = "storage"
+
+	// NetworkingCategory holds the kinds of networking entities:
+	// subnets, spaces and IP addresses.
+	NetworkingCategory Category = "networking"
+
+	// IdentityCategory holds the kinds of entities that
+	// identify people: users.
+	IdentityCategory Category = "identity"
+
+	// WorkloadCategory holds the kinds of entities that
+	// describe what is deployed: services, relations, charms,
+	// actions and payloads.
+	WorkloadCategory Category = "workload"
+
+	// ModelCategory holds the kinds of models, including
+	// the deprecated environment kind, and of the controllers
+	// hosting them.
+	ModelCategory Category = "model"
+)
+
+// discontinuedKindCategories holds the categories
+// of discontinued kinds.
+var discontinuedKindCategories = map[string]Category{
+	"network": NetworkingCategory,
+}
+
+// KindCategory returns the category of the given tag kind, which
+// may be a deprecated or discontinued kind. It returns
+// UnknownCategory for unknown kinds.
+func KindCategory(kind string) Category {
+	if h, ok := kindHandlers[kind]; ok {
+		return h.category
+	}
+	return discontinuedKindCategories[kind]
+}
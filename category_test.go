@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type categorySuite struct{}
+
+var _ = gc.Suite(&categorySuite{})
+
+func (s *categorySuite) TestKindCategory(c *gc.C) {
+	for i, test := range []struct {
+		kind   string
+		expect names.Category
+	}{
+		{names.MachineTagKind, names.AgentCategory},
+		{names.UnitTagKind, names.AgentCategory},
+		{names.StorageTagKind, names.StorageCategory},
+		{names.VolumeTagKind, names.StorageCategory},
+		{names.FilesystemTagKind, names.StorageCategory},
+		{names.VolumeAttachmentTagKind, names.StorageCategory},
+		{names.FilesystemAttachmentTagKind, names.StorageCategory},
+		{names.SubnetTagKind, names.NetworkingCategory},
+		{names.SpaceTagKind, names.NetworkingCategory},
+		{names.IPAddressTagKind, names.NetworkingCategory},
+		{"network", names.NetworkingCategory},
+		{names.UserTagKind, names.IdentityCategory},
+		{names.ServiceTagKind, names.WorkloadCategory},
+		{names.RelationTagKind, names.WorkloadCategory},
+		{names.CharmTagKind, names.WorkloadCategory},
+		{names.ActionTagKind, names.WorkloadCategory},
+		{names.PayloadTagKind, names.WorkloadCategory},
+		{names.ModelTagKind, names.ModelCategory},
+		{names.EnvironTagKind, names.ModelCategory},
+		{names.ControllerTagKind, names.ModelCategory},
+		{"foo", names.UnknownCategory},
+		{"", names.UnknownCategory},
+	} {
+		c.Logf("test %d: %q", i, test.kind)
+		c.Check(names.KindCategory(test.kind), gc.Equals, test.expect)
+	}
+}
+
+func (s *categorySuite) TestEveryKindHasCategory(c *gc.C) {
+	for _, kind := range namestest.Kinds() {
+		c.Check(names.KindCategory(kind), gc.Not(gc.Equals), names.UnknownCategory, gc.Commentf("%s", kind))
+	}
+	for _, kind := range names.DiscontinuedKinds() {
+		c.Check(names.KindCategory(kind), gc.Not(gc.Equals), names.UnknownCategory, gc.Commentf("%s", kind))
+	}
+}
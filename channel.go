@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Channel risk levels, from most to least stable.
+const (
+	StableRisk    = "stable"
+	CandidateRisk = "candidate"
+	BetaRisk      = "beta"
+	EdgeRisk      = "edge"
+)
+
+var validChannelPart = newLazyRegexp("^[a-zA-Z0-9][a-zA-Z0-9._-]*$")
+
+// Channel identifies a charm hub channel, written
+// "[<track>/]<risk>[/<branch>]", e.g. "latest/stable"
+// or "8.0/edge/feature-x".
+type Channel struct {
+	// Track holds the optional track of the channel.
+	Track string
+
+	// Risk holds the risk level of the channel,
+	// one of the *Risk constants.
+	Risk string
+
+	// Branch holds the optional branch of the channel.
+	Branch string
+}
+
+// ParseChannel parses a channel name.
+func ParseChannel(s string) (Channel, error) {
+	var ch Channel
+	var hasTrack, hasBranch bool
+	parts := strings.Split(s, "/")
+	switch len(parts) {
+	case 1:
+		ch.Risk = parts[0]
+	case 2:
+		if isChannelRisk(parts[0]) {
+			ch.Risk, ch.Branch = parts[0], parts[1]
+			hasBranch = true
+		} else {
+			ch.Track, ch.Risk = parts[0], parts[1]
+			hasTrack = true
+		}
+	case 3:
+		ch.Track, ch.Risk, ch.Branch = parts[0], parts[1], parts[2]
+		hasTrack, hasBranch = true, true
+	default:
+		return Channel{}, fmt.Errorf("%q is not a valid channel: too many parts", s)
+	}
+	if hasTrack && !validChannelPart.MatchString(ch.Track) {
+		return Channel{}, fmt.Errorf("%q is not a valid channel: invalid track %q", s, ch.Track)
+	}
+	if !isChannelRisk(ch.Risk) {
+		return Channel{}, fmt.Errorf("%q is not a valid channel: invalid risk %q", s, ch.Risk)
+	}
+	if hasBranch && !validChannelPart.MatchString(ch.Branch) {
+		return Channel{}, fmt.Errorf("%q is not a valid channel: invalid branch %q", s, ch.Branch)
+	}
+	return ch, nil
+}
+
+// IsValidChannel returns whether name is a valid channel name.
+func IsValidChannel(name string) bool {
+	_, err := ParseChannel(name)
+	return err == nil
+}
+
+// String returns the channel name in the form accepted by ParseChannel.
+func (ch Channel) String() string {
+	s := ch.Risk
+	if ch.Track != "" {
+		s = ch.Track + "/" + s
+	}
+	if ch.Branch != "" {
+		s += "/" + ch.Branch
+	}
+	return s
+}
+
+func isChannelRisk(s string) bool {
+	switch s {
+	case StableRisk, CandidateRisk, BetaRisk, EdgeRisk:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type channelSuite struct{}
+
+var _ = gc.Suite(&channelSuite{})
+
+func (s *channelSuite) TestParseChannel(c *gc.C) {
+	for i, test := range []struct {
+		name   string
+		expect names.Channel
+		err    string
+	}{{
+		name:   "stable",
+		expect: names.Channel{Risk: "stable"},
+	}, {
+		name:   "latest/stable",
+		expect: names.Channel{Track: "latest", Risk: "stable"},
+	}, {
+		name:   "8.0/edge/feature-x",
+		expect: names.Channel{Track: "8.0", Risk: "edge", Branch: "feature-x"},
+	}, {
+		name:   "candidate/hotfix_1",
+		expect: names.Channel{Risk: "candidate", Branch: "hotfix_1"},
+	}, {
+		name:   "2.x/beta",
+		expect: names.Channel{Track: "2.x", Risk: "beta"},
+	}, {
+		name: "",
+		err:  `"" is not a valid channel: invalid risk ""`,
+	}, {
+		name: "latest",
+		err:  `"latest" is not a valid channel: invalid risk "latest"`,
+	}, {
+		name: "latest/unstable",
+		err:  `"latest/unstable" is not a valid channel: invalid risk "unstable"`,
+	}, {
+		name: "/stable",
+		err:  `"/stable" is not a valid channel: invalid track ""`,
+	}, {
+		name: "stable/",
+		err:  `"stable/" is not a valid channel: invalid branch ""`,
+	}, {
+		name: "latest/stable/-x",
+		err:  `"latest/stable/-x" is not a valid channel: invalid branch "-x"`,
+	}, {
+		name: "latest/stable/a/b",
+		err:  `"latest/stable/a/b" is not a valid channel: too many parts`,
+	}} {
+		c.Logf("test %d: %q", i, test.name)
+		ch, err := names.ParseChannel(test.name)
+		c.Check(names.IsValidChannel(test.name), gc.Equals, test.err == "")
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(ch, jc.DeepEquals, test.expect)
+		c.Check(ch.String(), gc.Equals, test.name)
+	}
+}
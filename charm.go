@@ -5,7 +5,8 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
+	"strings"
 )
 
 // CharmTagKind specifies charm tag kind
@@ -14,13 +15,20 @@ const CharmTagKind = "charm"
 // Valid charm url is of the form
 // schema:~user/series/name-revision
 // where
-//     schema    is optional and can be either "local" or "cs".
+//     schema    is optional and can be "local", "cs" or "ch".
 //               When not supplied, "cs" is implied.
 //     user      is optional and is only applicable for "cs" schema
 //     series    is optional and is a valid series name
 //     name      is mandatory and is the name of the charm
 //     revision  is optional and can be -1 if revision is unset
 
+// Charm URL schemas.
+const (
+	LocalCharmSchema      = "local"
+	CharmStoreCharmSchema = "cs"
+	CharmHubCharmSchema   = "ch"
+)
+
 var (
 	// SeriesSnippet is a regular expression representing series
 	SeriesSnippet = "[a-z]+([a-z0-9]+)?"
@@ -28,18 +36,73 @@ var (
 	// CharmNameSnippet is a regular expression representing charm name
 	CharmNameSnippet = "[a-z][a-z0-9]*(-[a-z0-9]*[a-z][a-z0-9]*)*"
 
-	localSchemaSnippet      = "local:"
-	charmStoreSchemaSnippet = "cs:(~" + validUserPart + "/)?"
-	revisionSnippet         = "(-1|0|[1-9][0-9]*)"
+	revisionSnippet = "(-1|0|[1-9][0-9]*)"
 
-	validCharmRegEx = regexp.MustCompile("^(" +
-		localSchemaSnippet + "|" +
-		charmStoreSchemaSnippet + ")?(" +
-		SeriesSnippet + "/)?" +
-		CharmNameSnippet + "(-" +
-		revisionSnippet + ")?$")
+	validSeries            = newLazyRegexp("^" + SeriesSnippet + "$")
+	validCharmName         = newLazyRegexp("^" + CharmNameSnippet + "$")
+	validCharmNameRevision = newLazyRegexp("^(" + CharmNameSnippet + ")(-" + revisionSnippet + ")?$")
 )
 
+// charmURL holds the parts of a charm url.
+type charmURL struct {
+	schema   string
+	user     string
+	series   string
+	name     string
+	revision int
+}
+
+// parseCharmURL splits the given charm url into its parts.
+// An omitted revision is reported as -1.
+func parseCharmURL(url string) (charmURL, error) {
+	u := charmURL{
+		schema:   CharmStoreCharmSchema,
+		revision: -1,
+	}
+	rest := url
+	if i := strings.Index(rest, ":"); i != -1 {
+		u.schema, rest = rest[:i], rest[i+1:]
+		switch u.schema {
+		case LocalCharmSchema, CharmStoreCharmSchema, CharmHubCharmSchema:
+		default:
+			return charmURL{}, fmt.Errorf("charm url %q has invalid schema %q", url, u.schema)
+		}
+	}
+	if strings.HasPrefix(rest, "~") {
+		if u.schema != CharmStoreCharmSchema {
+			return charmURL{}, fmt.Errorf("charm url %q cannot have a user with schema %q", url, u.schema)
+		}
+		i := strings.Index(rest, "/")
+		if i == -1 {
+			return charmURL{}, fmt.Errorf("charm url %q has a user but no name", url)
+		}
+		u.user, rest = rest[1:i], rest[i+1:]
+		if !validUserName.MatchString(u.user) {
+			return charmURL{}, fmt.Errorf("charm url %q has invalid user %q", url, u.user)
+		}
+	}
+	parts := strings.Split(rest, "/")
+	switch len(parts) {
+	case 1:
+	case 2:
+		u.series = parts[0]
+		if !validSeries.MatchString(u.series) {
+			return charmURL{}, fmt.Errorf("charm url %q has invalid series %q", url, u.series)
+		}
+	default:
+		return charmURL{}, fmt.Errorf("charm url %q has too many parts", url)
+	}
+	m := validCharmNameRevision.FindStringSubmatch(parts[len(parts)-1])
+	if m == nil {
+		return charmURL{}, fmt.Errorf("charm url %q has invalid name or revision %q", url, parts[len(parts)-1])
+	}
+	u.name = m[1]
+	if rev := m[len(m)-1]; rev != "" {
+		u.revision, _ = strconv.Atoi(rev)
+	}
+	return u, nil
+}
+
 // CharmTag represents tag for charm
 // using charm's URL
 type CharmTag struct {
@@ -58,6 +121,13 @@ func (t CharmTag) Kind() string { return CharmTagKind }
 // Returns charm URL.
 func (t CharmTag) Id() string { return t.url }
 
+// Validate implements SelfValidator.
+func (t CharmTag) Validate() error { return validateTag(t) }
+
+// PathSegment returns the tag in a form safe for use as a single
+// path segment (see TagFromPathSegment).
+func (t CharmTag) PathSegment() string { return pathSegment(t) }
+
 // NewCharmTag returns the tag for the charm with the given url.
 // It will panic if the given charm url is not valid.
 func NewCharmTag(charmURL string) CharmTag {
@@ -82,7 +152,67 @@ func ParseCharmTag(charmTag string) (CharmTag, error) {
 	return ct, nil
 }
 
+// Schema returns the schema of the charm url, which is
+// CharmStoreCharmSchema if the url does not specify one.
+func (t CharmTag) Schema() string {
+	return t.parts().schema
+}
+
+// User returns the user owning the charm in the charm store,
+// or the empty string if the charm url does not specify one.
+func (t CharmTag) User() string {
+	return t.parts().user
+}
+
+// Name returns the name of the charm.
+func (t CharmTag) Name() string {
+	return t.parts().name
+}
+
+// Series returns the series of the charm, and whether
+// the charm url specifies one.
+func (t CharmTag) Series() (string, bool) {
+	u := t.parts()
+	return u.series, u.series != ""
+}
+
+// Revision returns the revision of the charm, and whether the charm
+// url specifies one. A revision of -1 is treated as unspecified.
+func (t CharmTag) Revision() (int, bool) {
+	u := t.parts()
+	return u.revision, u.revision != -1
+}
+
+// WithRevision returns the tag of the charm with the same url as t,
+// but with the given revision. A revision of -1 removes the revision
+// from the url. It will panic if the revision is less than -1.
+func (t CharmTag) WithRevision(n int) CharmTag {
+	if n < -1 {
+		panic(fmt.Sprintf("%d is not a valid charm revision", n))
+	}
+	i := strings.LastIndexAny(t.url, ":/") + 1
+	url := t.url[:i] + t.parts().name
+	if n != -1 {
+		url += "-" + strconv.Itoa(n)
+	}
+	return NewCharmTag(url)
+}
+
+// parts returns the parts of the tag's charm url. The url of a
+// tag created by NewCharmTag or ParseCharmTag is always valid.
+func (t CharmTag) parts() charmURL {
+	u, _ := parseCharmURL(t.url)
+	return u
+}
+
 // IsValidCharm returns whether name is a valid charm url.
 func IsValidCharm(url string) bool {
-	return validCharmRegEx.MatchString(url)
+	_, err := parseCharmURL(url)
+	return err == nil
+}
+
+// IsValidCharmName returns whether name is a valid bare charm name,
+// as given in a charm's metadata, rather than a full charm url.
+func IsValidCharmName(name string) bool {
+	return validCharmName.MatchString(name)
 }
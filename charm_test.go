@@ -37,6 +37,10 @@ var validCharmURLs = []string{"charm",
 	"charm-1",
 	"series/charm",
 	"series/charm-1",
+	"ch:charm",
+	"ch:charm-1",
+	"ch:series/charm",
+	"ch:series/charm-1",
 }
 
 func (s *charmSuite) TestValidCharmURLs(c *gc.C) {
@@ -54,6 +58,12 @@ func (s *charmSuite) TestInvalidCharmURLs(c *gc.C) {
 		"local:charm--2",             // false: only -1 is a valid negative revision
 		"blah:charm-2",               // false: invalid schema
 		"local:series/charm-01",      // false: revision is funny
+		"ch:~user/charm",             // false: user on ch
+		"cs:~user",                   // false: user without name
+		"cs:~-user/charm",            // false: invalid user
+		"cs:series/charm/1",          // false: too many parts
+		"cs:1series/charm",           // false: invalid series
+		"charm_name",                 // false: invalid name
 	}
 	for _, url := range invalidURLs {
 		c.Logf("Processing tag %q", url)
@@ -61,6 +71,94 @@ func (s *charmSuite) TestInvalidCharmURLs(c *gc.C) {
 	}
 }
 
+func (s *charmSuite) TestCharmURLParts(c *gc.C) {
+	for i, test := range []struct {
+		url    string
+		schema string
+		user   string
+		name   string
+	}{
+		{"charm", "cs", "", "charm"},
+		{"cs:~user/series/my-charm-2", "cs", "user", "my-charm"},
+		{"local:series/charm-0", "local", "", "charm"},
+		{"ch:charm--1", "ch", "", "charm"},
+		{"series/charm2-b1", "cs", "", "charm2-b1"},
+	} {
+		c.Logf("test %d: %q", i, test.url)
+		tag := names.NewCharmTag(test.url)
+		c.Check(tag.Schema(), gc.Equals, test.schema)
+		c.Check(tag.User(), gc.Equals, test.user)
+		c.Check(tag.Name(), gc.Equals, test.name)
+		c.Check(tag.Id(), gc.Equals, test.url)
+	}
+}
+
+func (s *charmSuite) TestCharmSeriesAndRevision(c *gc.C) {
+	for i, test := range []struct {
+		url         string
+		series      string
+		hasSeries   bool
+		revision    int
+		hasRevision bool
+	}{
+		{"charm", "", false, -1, false},
+		{"charm--1", "", false, -1, false},
+		{"cs:~user/trusty/charm-0", "trusty", true, 0, true},
+		{"local:xenial/my-charm-12", "xenial", true, 12, true},
+		{"ch:charm-3", "", false, 3, true},
+	} {
+		c.Logf("test %d: %q", i, test.url)
+		tag := names.NewCharmTag(test.url)
+		series, ok := tag.Series()
+		c.Check(series, gc.Equals, test.series)
+		c.Check(ok, gc.Equals, test.hasSeries)
+		revision, ok := tag.Revision()
+		c.Check(revision, gc.Equals, test.revision)
+		c.Check(ok, gc.Equals, test.hasRevision)
+	}
+}
+
+func (s *charmSuite) TestCharmWithRevision(c *gc.C) {
+	for i, test := range []struct {
+		url      string
+		revision int
+		expect   string
+	}{
+		{"charm", 3, "charm-3"},
+		{"charm-1", 0, "charm-0"},
+		{"cs:~user/trusty/my-charm-5", 6, "cs:~user/trusty/my-charm-6"},
+		{"local:charm--1", 2, "local:charm-2"},
+		{"ch:xenial/charm-7", -1, "ch:xenial/charm"},
+	} {
+		c.Logf("test %d: %q with revision %d", i, test.url, test.revision)
+		tag := names.NewCharmTag(test.url).WithRevision(test.revision)
+		c.Check(tag, gc.Equals, names.NewCharmTag(test.expect))
+	}
+	c.Check(func() { names.NewCharmTag("charm").WithRevision(-2) }, gc.PanicMatches, `-2 is not a valid charm revision`)
+}
+
+func (s *charmSuite) TestIsValidCharmName(c *gc.C) {
+	for i, test := range []struct {
+		name  string
+		valid bool
+	}{
+		{"", false},
+		{"mysql", true},
+		{"my-sql2", true},
+		{"wordpress-k8s", true},
+		{"foo_bar", false},
+		{"Mysql", false},
+		{"2mysql", false},
+		{"mysql-2", false},
+		{"mysql-", false},
+		{"cs:mysql", false},
+		{"trusty/mysql", false},
+	} {
+		c.Logf("test %d: %q", i, test.name)
+		c.Check(names.IsValidCharmName(test.name), gc.Equals, test.valid)
+	}
+}
+
 func (s *charmSuite) TestParseCharmTagValid(c *gc.C) {
 	for _, tag := range validCharmURLs {
 		c.Logf("Processing tag %q", tag)
@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The jujutag command validates, parses and converts Juju tags,
+// for use in shell scripts and when debugging.
+//
+// Usage:
+//
+//	jujutag validate TAG...
+//	jujutag parse TAG...
+//	jujutag kind TAG...
+//	jujutag convert FORMAT TAG...
+//
+// validate prints nothing, but exits with status 1 if any tag is
+// invalid, reporting why on standard error. parse prints the kind
+// and id of each tag, separated by a tab. kind prints the kind of
+// each tag. convert prints each tag converted to the given format,
+// one of:
+//
+//	model     the current form, e.g. environment tags become model tags
+//	environ   the original wire format, in which models are environments
+//	dirname   the name of the agent's directory, for machines and units
+//	readable  a readable form, e.g. "unit 0 of mysql"
+//	short     the id, with model UUIDs shortened
+//
+// Every command processes all its tags, printing the results of those
+// that are valid, before exiting with status 1 if any were invalid.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/juju/names"
+)
+
+const usage = `usage:
+	jujutag validate TAG...
+	jujutag parse TAG...
+	jujutag kind TAG...
+	jujutag convert FORMAT TAG...
+`
+
+// converters holds the conversions supported by the
+// convert command, by format name.
+var converters = map[string]func(names.Tag) (string, error){
+	"model": func(tag names.Tag) (string, error) {
+		return names.Canonicalize(tag).String(), nil
+	},
+	"environ": func(tag names.Tag) (string, error) {
+		tag, err := names.TagForVersion(tag, names.SerializationV1)
+		if err != nil {
+			return "", err
+		}
+		return tag.String(), nil
+	},
+	"dirname": func(tag names.Tag) (string, error) {
+		agentTag, ok := tag.(names.AgentTag)
+		if !ok {
+			return "", fmt.Errorf("%s %q has no agent directory", tag.Kind(), tag.Id())
+		}
+		return names.TagToAgentDirName(agentTag), nil
+	},
+	"readable": func(tag names.Tag) (string, error) {
+		return names.ReadableString(tag), nil
+	},
+	"short": func(tag names.Tag) (string, error) {
+		return names.ShortString(tag), nil
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments,
+// returning its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	cmd, args := args[0], args[1:]
+	var print func(names.Tag) (string, error)
+	switch cmd {
+	case "validate":
+		print = func(names.Tag) (string, error) { return "", nil }
+	case "parse":
+		print = func(tag names.Tag) (string, error) {
+			return tag.Kind() + "\t" + tag.Id(), nil
+		}
+	case "kind":
+		print = func(tag names.Tag) (string, error) {
+			return tag.Kind(), nil
+		}
+	case "convert":
+		if len(args) == 0 {
+			fmt.Fprint(stderr, usage)
+			return 2
+		}
+		var ok bool
+		if print, ok = converters[args[0]]; !ok {
+			fmt.Fprintf(stderr, "jujutag: unknown format %q, expected one of: %s\n", args[0], formats())
+			return 2
+		}
+		args = args[1:]
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "jujutag: unknown command %q\n%s", cmd, usage)
+		return 2
+	}
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	status := 0
+	for _, arg := range args {
+		tag, err := names.ParseTag(arg)
+		var out string
+		if err == nil {
+			out, err = print(tag)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "jujutag: %v\n", err)
+			status = 1
+			continue
+		}
+		if out != "" {
+			fmt.Fprintln(stdout, out)
+		}
+	}
+	return status
+}
+
+// formats returns the names of the formats
+// supported by the convert command.
+func formats() string {
+	var formats []string
+	for format := range converters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return strings.Join(formats, ", ")
+}
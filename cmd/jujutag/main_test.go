@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"bytes"
+	stdtesting "testing"
+
+	gc "gopkg.in/check.v1"
+)
+
+func Test(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type mainSuite struct{}
+
+var _ = gc.Suite(&mainSuite{})
+
+const modelUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+var runTests = []struct {
+	args   []string
+	status int
+	stdout string
+	stderr string
+}{{
+	args: []string{"validate", "machine-0", "unit-mysql-0"},
+}, {
+	args:   []string{"validate", "machine-0", "unit-mysql", "foo"},
+	status: 1,
+	stderr: "jujutag: \"unit-mysql\" is not a valid unit tag\njujutag: \"foo\" is not a valid tag\n",
+}, {
+	args:   []string{"parse", "machine-0-lxd-1", "unit-mysql-0", "relation-wordpress.db#mysql.server"},
+	stdout: "machine\t0/lxd/1\nunit\tmysql/0\nrelation\twordpress:db mysql:server\n",
+}, {
+	args:   []string{"kind", "machine-0", "bad", "service-mysql"},
+	status: 1,
+	stdout: "machine\nservice\n",
+	stderr: "jujutag: \"bad\" is not a valid tag\n",
+}, {
+	args:   []string{"convert", "model", "environment-" + modelUUID, "machine-0"},
+	stdout: "model-" + modelUUID + "\nmachine-0\n",
+}, {
+	args:   []string{"convert", "environ", "model-" + modelUUID},
+	stdout: "environment-" + modelUUID + "\n",
+}, {
+	args:   []string{"convert", "dirname", "unit-mysql-0", "service-mysql"},
+	status: 1,
+	stdout: "unit-mysql-0\n",
+	stderr: "jujutag: service \"mysql\" has no agent directory\n",
+}, {
+	args:   []string{"convert", "readable", "unit-mysql-0"},
+	stdout: "unit 0 of mysql\n",
+}, {
+	args:   []string{"convert", "short", "model-" + modelUUID, "unit-mysql-0"},
+	stdout: "f47ac10b\nmysql/0\n",
+}, {
+	args:   []string{"convert", "json", "machine-0"},
+	status: 2,
+	stderr: "jujutag: unknown format \"json\", expected one of: dirname, environ, model, readable, short\n",
+}, {
+	args:   []string{"convert"},
+	status: 2,
+	stderr: usage,
+}, {
+	args:   []string{"parse"},
+	status: 2,
+	stderr: usage,
+}, {
+	args:   []string{},
+	status: 2,
+	stderr: usage,
+}, {
+	args:   []string{"frobnicate", "machine-0"},
+	status: 2,
+	stderr: "jujutag: unknown command \"frobnicate\"\n" + usage,
+}, {
+	args:   []string{"help"},
+	stdout: usage,
+}}
+
+func (s *mainSuite) TestRun(c *gc.C) {
+	for i, test := range runTests {
+		c.Logf("test %d: %q", i, test.args)
+		var stdout, stderr bytes.Buffer
+		status := run(test.args, &stdout, &stderr)
+		c.Check(status, gc.Equals, test.status)
+		c.Check(stdout.String(), gc.Equals, test.stdout)
+		c.Check(stderr.String(), gc.Equals, test.stderr)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContainerSpec specifies a container to be created, as given to
+// commands such as add-machine and deploy --to.
+type ContainerSpec struct {
+	// ContainerType holds the type of the container.
+	ContainerType string
+
+	// Machine holds the tag of the existing machine that will host
+	// the container. It is the zero MachineTag if the container is
+	// to be hosted by a new machine.
+	Machine MachineTag
+}
+
+// ParseContainerSpec parses a container specification of the form
+// "<type>", "<type>:<machine-id>" or "<type>:new", for example "lxd",
+// "lxd:4" or "kvm:new". The first and last forms both specify a
+// container on a new machine.
+func ParseContainerSpec(spec string) (ContainerSpec, error) {
+	containerType, machineId := spec, "new"
+	if i := strings.Index(spec, ":"); i != -1 {
+		containerType, machineId = spec[:i], spec[i+1:]
+	}
+	if err := defaultMachineIdRules.validateContainerType(containerType); err != nil {
+		return ContainerSpec{}, fmt.Errorf("invalid container spec %q: %v", spec, err)
+	}
+	result := ContainerSpec{ContainerType: containerType}
+	if machineId != "new" {
+		if !IsValidMachine(machineId) {
+			return ContainerSpec{}, fmt.Errorf("invalid container spec %q: invalid machine id %q", spec, machineId)
+		}
+		result.Machine = NewMachineTag(machineId)
+	}
+	return result, nil
+}
+
+// Target returns the tag of the existing machine that will host the
+// container, and whether there is one.
+func (s ContainerSpec) Target() (MachineTag, bool) {
+	return s.Machine, s.Machine != MachineTag{}
+}
+
+// String returns the specification in the form accepted by
+// ParseContainerSpec.
+func (s ContainerSpec) String() string {
+	if machine, ok := s.Target(); ok {
+		return s.ContainerType + ":" + machine.Id()
+	}
+	return s.ContainerType
+}
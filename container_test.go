@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type containerSuite struct{}
+
+var _ = gc.Suite(&containerSuite{})
+
+func (s *containerSuite) TestParseContainerSpec(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect names.ContainerSpec
+		target bool
+		str    string
+		err    string
+	}{{
+		spec:   "lxd",
+		expect: names.ContainerSpec{ContainerType: "lxd"},
+		str:    "lxd",
+	}, {
+		spec:   "kvm:new",
+		expect: names.ContainerSpec{ContainerType: "kvm"},
+		str:    "kvm",
+	}, {
+		spec:   "lxd:4",
+		expect: names.ContainerSpec{ContainerType: "lxd", Machine: names.NewMachineTag("4")},
+		target: true,
+		str:    "lxd:4",
+	}, {
+		spec:   "lxd:0/kvm/1",
+		expect: names.ContainerSpec{ContainerType: "lxd", Machine: names.NewMachineTag("0/kvm/1")},
+		target: true,
+		str:    "lxd:0/kvm/1",
+	}, {
+		spec: "lxd:",
+		err:  `invalid container spec "lxd:": invalid machine id ""`,
+	}, {
+		spec: "lxd:foo",
+		err:  `invalid container spec "lxd:foo": invalid machine id "foo"`,
+	}, {
+		spec: "docker:4",
+		err:  `invalid container spec "docker:4": unknown container type "docker"`,
+	}, {
+		spec: "",
+		err:  `invalid container spec "": invalid container type ""`,
+	}, {
+		spec: "4",
+		err:  `invalid container spec "4": invalid container type "4"`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		spec, err := names.ParseContainerSpec(test.spec)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(spec, jc.DeepEquals, test.expect)
+		machine, ok := spec.Target()
+		c.Check(ok, gc.Equals, test.target)
+		c.Check(machine, gc.Equals, test.expect.Machine)
+		c.Check(spec.String(), gc.Equals, test.str)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+const ControllerTagKind = "controller"
+
+// ControllerTag represents a tag used to describe a controller.
+type ControllerTag struct {
+	uuid string
+}
+
+// NewControllerTag returns the tag of a controller with the given
+// controller UUID. UUIDs in upper or mixed case are converted to
+// lower case.
+func NewControllerTag(uuid string) ControllerTag {
+	return ControllerTag{uuid: lowerUUID(uuid)}
+}
+
+// ParseControllerTag parses a controller tag string.
+func ParseControllerTag(controllerTag string) (ControllerTag, error) {
+	tag, err := ParseTag(controllerTag)
+	if err != nil {
+		return ControllerTag{}, err
+	}
+	ct, ok := tag.(ControllerTag)
+	if !ok {
+		return ControllerTag{}, invalidTagError(controllerTag, ControllerTagKind)
+	}
+	return ct, nil
+}
+
+func (t ControllerTag) String() string      { return t.Kind() + "-" + t.Id() }
+func (t ControllerTag) Kind() string        { return ControllerTagKind }
+func (t ControllerTag) Id() string          { return t.uuid }
+func (t ControllerTag) Validate() error     { return validateTag(t) }
+func (t ControllerTag) PathSegment() string { return pathSegment(t) }
+
+// IsValidController returns whether id is a valid controller UUID.
+// Unlike model ids, controller ids must be exactly a UUID.
+func IsValidController(id string) bool {
+	return IsValidUUID(id)
+}
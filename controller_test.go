@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type controllerSuite struct{}
+
+var _ = gc.Suite(&controllerSuite{})
+
+const controllerUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+var parseControllerTagTests = []struct {
+	tag      string
+	expected names.Tag
+	err      error
+}{{
+	tag: "",
+	err: names.InvalidTagError("", ""),
+}, {
+	tag:      "controller-" + controllerUUID,
+	expected: names.NewControllerTag(controllerUUID),
+}, {
+	tag:      "controller-DEADBEEF-0BAD-400D-8000-4B1D0D06F00D",
+	expected: names.NewControllerTag(controllerUUID),
+}, {
+	tag: "controller-foo",
+	err: names.InvalidTagError("controller-foo", names.ControllerTagKind),
+}, {
+	tag: "controller-x" + controllerUUID,
+	err: names.InvalidTagError("controller-x"+controllerUUID, names.ControllerTagKind),
+}, {
+	tag: "model-" + controllerUUID,
+	err: names.InvalidTagError("model-"+controllerUUID, names.ControllerTagKind),
+}}
+
+func (s *controllerSuite) TestParseControllerTag(c *gc.C) {
+	for i, t := range parseControllerTagTests {
+		c.Logf("test %d: %s", i, t.tag)
+		got, err := names.ParseControllerTag(t.tag)
+		if err != nil || t.err != nil {
+			c.Check(err, gc.DeepEquals, t.err)
+			continue
+		}
+		c.Check(got, gc.FitsTypeOf, t.expected)
+		c.Check(got, gc.Equals, t.expected)
+	}
+}
+
+func (s *controllerSuite) TestNewControllerTagLowersUUID(c *gc.C) {
+	tag := names.NewControllerTag("DEADBEEF-0BAD-400D-8000-4B1D0D06F00D")
+	c.Assert(tag.Id(), gc.Equals, controllerUUID)
+	c.Assert(tag.String(), gc.Equals, "controller-"+controllerUUID)
+	c.Assert(names.ShortString(tag), gc.Equals, "deadbeef")
+}
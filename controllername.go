@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxControllerNameLength is the maximum length of a controller name.
+// It matches the length of a DNS label, so that a controller name
+// can always be used as part of a host name.
+const MaxControllerNameLength = 63
+
+var validControllerName = newLazyRegexp("^[a-z0-9]+[a-z0-9-]*$")
+
+// IsValidControllerName returns whether name is a valid name for a
+// controller, as given when bootstrapping or registering a controller.
+// Controller names consist of at most MaxControllerNameLength lower
+// case letters, digits and hyphens, and must not start with a hyphen.
+func IsValidControllerName(name string) bool {
+	return len(name) <= MaxControllerNameLength && validControllerName.MatchString(name)
+}
+
+// NormalizeControllerName returns the canonical form of the given
+// controller name, with surrounding white space removed and letters
+// converted to lower case. It returns an error if the result is not
+// a valid controller name.
+func NormalizeControllerName(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if !IsValidControllerName(normalized) {
+		return "", fmt.Errorf("%q is not a valid controller name", name)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type controllerNameSuite struct{}
+
+var _ = gc.Suite(&controllerNameSuite{})
+
+func (s *controllerNameSuite) TestIsValidControllerName(c *gc.C) {
+	for i, test := range []struct {
+		name  string
+		valid bool
+	}{
+		{"", false},
+		{"ctrl", true},
+		{"my-ctrl-2", true},
+		{"2ctrl", true},
+		{"a", true},
+		{"-ctrl", false},
+		{"Ctrl", false},
+		{"my_ctrl", false},
+		{"my ctrl", false},
+		{"aws/ctrl", false},
+		{strings.Repeat("a", 63), true},
+		{strings.Repeat("a", 64), false},
+	} {
+		c.Logf("test %d: %q", i, test.name)
+		c.Check(names.IsValidControllerName(test.name), gc.Equals, test.valid)
+	}
+}
+
+func (s *controllerNameSuite) TestNormalizeControllerName(c *gc.C) {
+	for i, test := range []struct {
+		name   string
+		expect string
+		err    string
+	}{{
+		name:   "ctrl",
+		expect: "ctrl",
+	}, {
+		name:   " My-Ctrl\n",
+		expect: "my-ctrl",
+	}, {
+		name: "",
+		err:  `"" is not a valid controller name`,
+	}, {
+		name: "my_ctrl",
+		err:  `"my_ctrl" is not a valid controller name`,
+	}, {
+		name: "-Ctrl",
+		err:  `"-Ctrl" is not a valid controller name`,
+	}} {
+		c.Logf("test %d: %q", i, test.name)
+		name, err := names.NormalizeControllerName(test.name)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(name, gc.Equals, test.expect)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"strings"
+)
+
+// Description holds a structured description of a tag, suitable for
+// rendering by user interfaces without re-parsing the tag's id.
+type Description struct {
+	// Kind holds the kind of the described tag.
+	Kind string
+
+	// Id holds the id of the described tag.
+	Id string
+
+	// DisplayName holds the human-readable name of the tag,
+	// as returned by ReadableString.
+	DisplayName string
+
+	// Components holds the parts of the tag's id, outermost first.
+	// For example, a container machine yields its host machine
+	// followed by one component per container level, and a
+	// relation yields one component per endpoint.
+	Components []Component
+}
+
+// Component is a single named part of a tag's id.
+type Component struct {
+	Name  string
+	Value string
+}
+
+// Describe returns a description of the given tag.
+// It returns the zero Description if tag is nil.
+func Describe(tag Tag) Description {
+	return LocalizedDescribe(nil, tag)
+}
+
+// LocalizedDescribe is like Describe, but renders the display
+// name through the given catalog. See LocalizedReadableString.
+func LocalizedDescribe(catalog Catalog, tag Tag) Description {
+	if tag == nil {
+		return Description{}
+	}
+	return Description{
+		Kind:        tag.Kind(),
+		Id:          tag.Id(),
+		DisplayName: LocalizedReadableString(catalog, tag),
+		Components:  describeComponents(tag),
+	}
+}
+
+func describeComponents(tag Tag) []Component {
+	switch tag := tag.(type) {
+	case MachineTag:
+		return machineComponents(tag.Id())
+	case UnitTag:
+		i := strings.LastIndex(tag.Id(), "/")
+		if i == -1 {
+			// Zero-value tags have no components.
+			return nil
+		}
+		return []Component{
+			{Name: ServiceTagKind, Value: tag.Id()[:i]},
+			{Name: "number", Value: tag.Id()[i+1:]},
+		}
+	case StorageTag:
+		i := strings.LastIndex(tag.Id(), "/")
+		if i == -1 {
+			return nil
+		}
+		return []Component{
+			{Name: "name", Value: tag.Id()[:i]},
+			{Name: "number", Value: tag.Id()[i+1:]},
+		}
+	case VolumeTag:
+		return scopedStorageComponents(VolumeTagKind, tag.Id())
+	case FilesystemTag:
+		return scopedStorageComponents(FilesystemTagKind, tag.Id())
+	case RelationTag:
+		var components []Component
+		for _, endpoint := range strings.Split(tag.Id(), " ") {
+			components = append(components, Component{Name: "endpoint", Value: endpoint})
+		}
+		return components
+	case UserTag:
+		return []Component{
+			{Name: "name", Value: tag.Name()},
+			{Name: "domain", Value: tag.Domain()},
+		}
+	}
+	return []Component{{Name: tag.Kind(), Value: tag.Id()}}
+}
+
+// machineComponents returns the host machine of the given
+// machine id followed by each of its container levels.
+func machineComponents(id string) []Component {
+	parts := strings.Split(id, "/")
+	components := []Component{{Name: MachineTagKind, Value: parts[0]}}
+	for i := 1; i+1 < len(parts); i += 2 {
+		components = append(components, Component{Name: parts[i], Value: parts[i+1]})
+	}
+	return components
+}
+
+// scopedStorageComponents returns the components of a volume or
+// filesystem id, which may be scoped to a machine or a unit.
+func scopedStorageComponents(kind, id string) []Component {
+	var components []Component
+	i := strings.LastIndex(id, "/")
+	if isUnitScopedStorageId(id) {
+		components = []Component{{Name: UnitTagKind, Value: id[:i]}}
+	} else if i != -1 {
+		components = machineComponents(id[:i])
+	}
+	return append(components, Component{Name: kind, Value: id[i+1:]})
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type describeSuite struct{}
+
+var _ = gc.Suite(&describeSuite{})
+
+var describeTests = []struct {
+	tag    names.Tag
+	expect names.Description
+}{{
+	tag:    nil,
+	expect: names.Description{},
+}, {
+	tag: names.NewMachineTag("0/lxc/3/kvm/1"),
+	expect: names.Description{
+		Kind:        names.MachineTagKind,
+		Id:          "0/lxc/3/kvm/1",
+		DisplayName: "container 1 (kvm) on container 3 (lxc) on machine 0",
+		Components: []names.Component{
+			{Name: "machine", Value: "0"},
+			{Name: "lxc", Value: "3"},
+			{Name: "kvm", Value: "1"},
+		},
+	},
+}, {
+	tag: names.NewUnitTag("rabbitmq-server/10"),
+	expect: names.Description{
+		Kind:        names.UnitTagKind,
+		Id:          "rabbitmq-server/10",
+		DisplayName: "unit 10 of rabbitmq-server",
+		Components: []names.Component{
+			{Name: "service", Value: "rabbitmq-server"},
+			{Name: "number", Value: "10"},
+		},
+	},
+}, {
+	tag: names.NewRelationTag("wordpress:db mysql:server"),
+	expect: names.Description{
+		Kind:        names.RelationTagKind,
+		Id:          "wordpress:db mysql:server",
+		DisplayName: "relation wordpress:db mysql:server",
+		Components: []names.Component{
+			{Name: "endpoint", Value: "wordpress:db"},
+			{Name: "endpoint", Value: "mysql:server"},
+		},
+	},
+}, {
+	tag: names.NewVolumeTag("0/lxc/1/5"),
+	expect: names.Description{
+		Kind:        names.VolumeTagKind,
+		Id:          "0/lxc/1/5",
+		DisplayName: "volume 0/lxc/1/5",
+		Components: []names.Component{
+			{Name: "machine", Value: "0"},
+			{Name: "lxc", Value: "1"},
+			{Name: "volume", Value: "5"},
+		},
+	},
+}, {
+	tag: names.NewFilesystemTag("2"),
+	expect: names.Description{
+		Kind:        names.FilesystemTagKind,
+		Id:          "2",
+		DisplayName: "filesystem 2",
+		Components:  []names.Component{{Name: "filesystem", Value: "2"}},
+	},
+}, {
+	tag: names.NewFilesystemTag("my-db/0/2"),
+	expect: names.Description{
+		Kind:        names.FilesystemTagKind,
+		Id:          "my-db/0/2",
+		DisplayName: "filesystem my-db/0/2",
+		Components: []names.Component{
+			{Name: "unit", Value: "my-db/0"},
+			{Name: "filesystem", Value: "2"},
+		},
+	},
+}, {
+	tag: names.NewStorageTag("data/3"),
+	expect: names.Description{
+		Kind:        names.StorageTagKind,
+		Id:          "data/3",
+		DisplayName: "storage data/3",
+		Components: []names.Component{
+			{Name: "name", Value: "data"},
+			{Name: "number", Value: "3"},
+		},
+	},
+}, {
+	tag: names.NewUserTag("bob"),
+	expect: names.Description{
+		Kind:        names.UserTagKind,
+		Id:          "bob",
+		DisplayName: "user bob",
+		Components: []names.Component{
+			{Name: "name", Value: "bob"},
+			{Name: "domain", Value: "local"},
+		},
+	},
+}, {
+	tag: names.NewServiceTag("mysql"),
+	expect: names.Description{
+		Kind:        names.ServiceTagKind,
+		Id:          "mysql",
+		DisplayName: "service mysql",
+		Components:  []names.Component{{Name: "service", Value: "mysql"}},
+	},
+}, {
+	tag: names.UnitTag{},
+	expect: names.Description{
+		Kind:        names.UnitTagKind,
+		DisplayName: "unit ",
+	},
+}, {
+	tag: names.StorageTag{},
+	expect: names.Description{
+		Kind:        names.StorageTagKind,
+		DisplayName: "storage ",
+	},
+}}
+
+func (*describeSuite) TestDescribe(c *gc.C) {
+	for i, test := range describeTests {
+		c.Logf("test %d: %v", i, test.tag)
+		c.Check(names.Describe(test.tag), jc.DeepEquals, test.expect)
+	}
+}
+
+func (*describeSuite) TestDescribeZeroValues(c *gc.C) {
+	for i, tag := range []names.Tag{
+		names.ActionTag{},
+		names.CharmTag{},
+		names.ControllerTag{},
+		names.EnvironTag{},
+		names.FilesystemTag{},
+		names.FilesystemAttachmentTag{},
+		names.IPAddressTag{},
+		names.MachineTag{},
+		names.ModelTag{},
+		names.PayloadTag{},
+		names.RelationTag{},
+		names.ServiceTag{},
+		names.SpaceTag{},
+		names.StorageTag{},
+		names.SubnetTag{},
+		names.UnitTag{},
+		names.UserTag{},
+		names.VolumeTag{},
+		names.VolumeAttachmentTag{},
+	} {
+		c.Logf("test %d: %#v", i, tag)
+		c.Check(names.Describe(tag).Kind, gc.Equals, tag.Kind())
+	}
+}
+
+func (*describeSuite) TestLocalizedDescribe(c *gc.C) {
+	d := names.LocalizedDescribe(testCatalog, names.NewUnitTag("mysql/0"))
+	c.Assert(d.DisplayName, gc.Equals, "unité 0 de mysql")
+	c.Assert(d.Id, gc.Equals, "mysql/0")
+}
@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EntityDocID returns the id of the document describing the entity
+// with the given tag in the model with the given UUID. Document ids
+// have the format "<model-uuid>:<tag>". The arguments are not
+// validated; see ParseEntityDocID.
+func EntityDocID(modelUUID string, tag Tag) string {
+	return modelUUID + ":" + tag.String()
+}
+
+// ParseEntityDocID parses a document id as returned by EntityDocID,
+// returning the model UUID and the entity tag it was composed from.
+func ParseEntityDocID(docID string) (string, Tag, error) {
+	return parseUUIDScoped(docID, "entity document id", ModelTagKind, IsValidModel)
+}
+
+// parseUUIDScoped parses a string of the form "<uuid>:<tag>", where
+// the UUID is that of a model or controller as given by scopeKind and
+// checked by isValidUUID, returning the UUID and the tag. The
+// description of the string is used in errors.
+func parseUUIDScoped(s, what, scopeKind string, isValidUUID func(string) bool) (string, Tag, error) {
+	i := strings.Index(s, ":")
+	if i == -1 {
+		return "", nil, fmt.Errorf("%q is not a valid %s", s, what)
+	}
+	uuid := s[:i]
+	if !isValidUUID(uuid) {
+		return "", nil, fmt.Errorf("%q is not a valid %s: invalid %s UUID %q", s, what, scopeKind, uuid)
+	}
+	tag, err := ParseTag(s[i+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("%q is not a valid %s: %v", s, what, err)
+	}
+	return uuid, tag, nil
+}
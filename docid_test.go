@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type docIDSuite struct{}
+
+var _ = gc.Suite(&docIDSuite{})
+
+const docModelUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func (s *docIDSuite) TestEntityDocID(c *gc.C) {
+	id := names.EntityDocID(docModelUUID, names.NewUnitTag("mysql/0"))
+	c.Assert(id, gc.Equals, docModelUUID+":unit-mysql-0")
+}
+
+func (s *docIDSuite) TestParseEntityDocID(c *gc.C) {
+	for i, test := range []struct {
+		docID string
+		tag   names.Tag
+		err   string
+	}{{
+		docID: docModelUUID + ":machine-0-lxc-1",
+		tag:   names.NewMachineTag("0/lxc/1"),
+	}, {
+		docID: docModelUUID + ":subnet-2001:db8::/32",
+		tag:   names.NewSubnetTag("2001:db8::/32"),
+	}, {
+		docID: docModelUUID + ":relation-wordpress.db#mysql.server",
+		tag:   names.NewRelationTag("wordpress:db mysql:server"),
+	}, {
+		docID: "machine-0",
+		err:   `"machine-0" is not a valid entity document id`,
+	}, {
+		docID: "foo:machine-0",
+		err:   `"foo:machine-0" is not a valid entity document id: invalid model UUID "foo"`,
+	}, {
+		docID: docModelUUID + ":0",
+		err:   `".*:0" is not a valid entity document id: "0" is not a valid tag`,
+	}} {
+		c.Logf("test %d: %q", i, test.docID)
+		modelUUID, tag, err := names.ParseEntityDocID(test.docID)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tag, gc.IsNil)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(modelUUID, gc.Equals, docModelUUID)
+		c.Check(tag, gc.Equals, test.tag)
+		c.Check(names.EntityDocID(modelUUID, tag), gc.Equals, test.docID)
+	}
+}
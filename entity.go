@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+)
+
+// EntityRef refers to an entity by its tag string. It has the same
+// shape, and JSON encoding, as the entities passed to and from the
+// Juju API, so tags can be converted to and from them directly.
+type EntityRef struct {
+	Tag string `json:"tag"`
+}
+
+// ToEntityRefs returns an entity ref for each of the given tags.
+// Nil tags yield refs with an empty tag string.
+func ToEntityRefs(tags []Tag) []EntityRef {
+	refs := make([]EntityRef, len(tags))
+	for i, tag := range tags {
+		if !isNilTag(tag) {
+			refs[i].Tag = tag.String()
+		}
+	}
+	return refs
+}
+
+// FromEntityRefs parses the tags of the given entity refs. If any
+// of them cannot be parsed, it returns an *EntityRefsError holding
+// the error for each, along with the tags of the others; the tags
+// of the refs in error are nil.
+func FromEntityRefs(refs []EntityRef) ([]Tag, error) {
+	tags := make([]Tag, len(refs))
+	var errs []error
+	for i, ref := range refs {
+		tag, err := ParseTag(ref.Tag)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(refs))
+			}
+			errs[i] = err
+			continue
+		}
+		tags[i] = tag
+	}
+	if errs != nil {
+		return tags, &EntityRefsError{Errors: errs}
+	}
+	return tags, nil
+}
+
+// EntityRefsError is returned by FromEntityRefs when
+// any of the entity refs cannot be parsed.
+type EntityRefsError struct {
+	// Errors holds the error for each entity ref, in order.
+	// It is nil for refs that were parsed successfully.
+	Errors []error
+}
+
+// Error implements error.
+func (e *EntityRefsError) Error() string {
+	var first error
+	n := 0
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			n++
+		}
+	}
+	if n == 1 {
+		return first.Error()
+	}
+	return fmt.Sprintf("%v (and %d more)", first, n-1)
+}
+
+// Unwrap returns the errors for the refs in error,
+// so that errors.Is and errors.As examine each of them.
+func (e *EntityRefsError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type entitySuite struct{}
+
+var _ = gc.Suite(&entitySuite{})
+
+func (s *entitySuite) TestToEntityRefs(c *gc.C) {
+	refs := names.ToEntityRefs([]names.Tag{
+		names.NewUnitTag("mysql/0"),
+		nil,
+		names.NewMachineTag("0/lxd/1"),
+	})
+	c.Assert(refs, jc.DeepEquals, []names.EntityRef{
+		{Tag: "unit-mysql-0"},
+		{Tag: ""},
+		{Tag: "machine-0-lxd-1"},
+	})
+	c.Assert(names.ToEntityRefs(nil), gc.HasLen, 0)
+}
+
+func (s *entitySuite) TestEntityRefJSON(c *gc.C) {
+	data, err := json.Marshal(struct {
+		Entities []names.EntityRef `json:"entities"`
+	}{names.ToEntityRefs([]names.Tag{names.NewUnitTag("mysql/0")})})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `{"entities":[{"tag":"unit-mysql-0"}]}`)
+}
+
+func (s *entitySuite) TestFromEntityRefs(c *gc.C) {
+	tags := []names.Tag{
+		names.NewUnitTag("mysql/0"),
+		names.NewServiceTag("mysql"),
+	}
+	got, err := names.FromEntityRefs(names.ToEntityRefs(tags))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, tags)
+}
+
+func (s *entitySuite) TestFromEntityRefsErrors(c *gc.C) {
+	tags, err := names.FromEntityRefs([]names.EntityRef{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-mysql"},
+		{Tag: "machine-0"},
+		{Tag: ""},
+	})
+	c.Assert(tags, jc.DeepEquals, []names.Tag{
+		names.NewUnitTag("mysql/0"),
+		nil,
+		names.NewMachineTag("0"),
+		nil,
+	})
+	c.Assert(err, gc.ErrorMatches, `"unit-mysql" is not a valid unit tag \(and 1 more\)`)
+	c.Assert(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+
+	var refsErr *names.EntityRefsError
+	c.Assert(errors.As(err, &refsErr), jc.IsTrue)
+	c.Assert(refsErr.Errors, jc.DeepEquals, []error{
+		nil,
+		names.InvalidTagError("unit-mysql", names.UnitTagKind),
+		nil,
+		names.InvalidTagError("", ""),
+	})
+
+	var tagErr *names.TagError
+	c.Assert(errors.As(err, &tagErr), jc.IsTrue)
+	c.Assert(tagErr.Tag, gc.Equals, "unit-mysql")
+}
+
+func (s *entitySuite) TestFromEntityRefsSingleError(c *gc.C) {
+	_, err := names.FromEntityRefs([]names.EntityRef{{Tag: "foo"}})
+	c.Assert(err, gc.ErrorMatches, `"foo" is not a valid tag`)
+}
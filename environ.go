@@ -11,8 +11,9 @@ type EnvironTag struct {
 }
 
 // NewEnvironTag returns the tag of an environment with the given environment UUID.
+// UUIDs in upper or mixed case are converted to lower case.
 func NewEnvironTag(uuid string) EnvironTag {
-	return EnvironTag{uuid: uuid}
+	return EnvironTag{uuid: lowerUUID(uuid)}
 }
 
 // ParseEnvironTag parses an environ tag string.
@@ -28,11 +29,18 @@ func ParseEnvironTag(environTag string) (EnvironTag, error) {
 	return et, nil
 }
 
-func (t EnvironTag) String() string { return t.Kind() + "-" + t.Id() }
-func (t EnvironTag) Kind() string   { return EnvironTagKind }
-func (t EnvironTag) Id() string     { return t.uuid }
+func (t EnvironTag) String() string      { return t.Kind() + "-" + t.Id() }
+func (t EnvironTag) Kind() string        { return EnvironTagKind }
+func (t EnvironTag) Id() string          { return t.uuid }
+func (t EnvironTag) Validate() error     { return validateTag(t) }
+func (t EnvironTag) PathSegment() string { return pathSegment(t) }
+
+// ToModelTag returns the model tag with the same UUID.
+func (t EnvironTag) ToModelTag() ModelTag {
+	return NewModelTag(t.uuid)
+}
 
 // IsValidEnvironment returns whether id is a valid environment UUID.
 func IsValidEnvironment(id string) bool {
-	return validUUID.MatchString(id)
+	return containsUUID(id)
 }
@@ -23,6 +23,9 @@ var parseEnvironTagTests = []struct {
 }, {
 	tag:      "environment-f47ac10b-58cc-4372-a567-0e02b2c3d479",
 	expected: names.NewEnvironTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+}, {
+	tag:      "environment-F47AC10B-58CC-4372-A567-0E02B2C3D479",
+	expected: names.NewEnvironTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
 }, {
 	tag: "dave",
 	err: names.InvalidTagError("dave", ""),
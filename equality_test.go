@@ -4,6 +4,9 @@
 package names
 
 import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 )
@@ -12,9 +15,9 @@ var tagEqualityTests = []struct {
 	expected Tag
 	want     Tag
 }{
-	{NewMachineTag("0"), MachineTag{id: "0"}},
-	{NewMachineTag("10/lxc/1"), MachineTag{id: "10-lxc-1"}},
-	{NewUnitTag("mysql/1"), UnitTag{name: "mysql-1"}},
+	{NewMachineTag("0"), MachineTag{tag: "machine-0"}},
+	{NewMachineTag("10/lxc/1"), MachineTag{tag: "machine-10-lxc-1"}},
+	{NewUnitTag("mysql/1"), UnitTag{tag: "unit-mysql-1"}},
 	{NewServiceTag("ceph"), ServiceTag{Name: "ceph"}},
 	{NewRelationTag("wordpress:haproxy"), RelationTag{key: "wordpress.haproxy"}},
 	{NewEnvironTag("deadbeef-0123-4567-89ab-feedfacebeef"), EnvironTag{uuid: "deadbeef-0123-4567-89ab-feedfacebeef"}},
@@ -34,6 +37,46 @@ func (s *equalitySuite) TestTagEquality(c *gc.C) {
 	}
 }
 
+// listTag is a tag whose type cannot be compared with ==.
+type listTag []string
+
+func (t listTag) String() string { return t.Kind() + "-" + t.Id() }
+func (t listTag) Kind() string   { return "list" }
+func (t listTag) Id() string     { return strings.Join(t, ".") }
+
+func (s *equalitySuite) TestEqual(c *gc.C) {
+	for _, tt := range tagEqualityTests {
+		c.Check(Equal(tt.want, tt.expected), jc.IsTrue)
+	}
+	machine := NewMachineTag("0")
+	var nilMachine *MachineTag
+	var nilList listTag
+	for i, test := range []struct {
+		a, b   Tag
+		expect bool
+	}{
+		{a: nil, b: nil, expect: true},
+		{a: nilMachine, b: nil, expect: true},
+		{a: nilMachine, b: nilMachine, expect: true},
+		{a: nilList, b: nil, expect: true},
+		{a: machine, b: nil},
+		{a: machine, b: nilMachine},
+		{a: &machine, b: machine, expect: true},
+		{a: machine, b: NewMachineTag("1")},
+		{a: machine, b: NewServiceTag("machine")},
+		{a: NewEnvironTag(testUUID), b: NewModelTag(testUUID)},
+		{a: listTag{"a", "b"}, b: listTag{"a", "b"}, expect: true},
+		{a: listTag{"a", "b"}, b: listTag{"a"}},
+		{a: listTag{"a"}, b: machine},
+	} {
+		c.Logf("test %d: %#v %#v", i, test.a, test.b)
+		c.Check(Equal(test.a, test.b), gc.Equals, test.expect)
+		c.Check(Equal(test.b, test.a), gc.Equals, test.expect)
+	}
+}
+
+const testUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
 func stringToUUID(id string) utils.UUID {
 	uuid, err := utils.UUIDFromString(id)
 	if err != nil {
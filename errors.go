@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrInvalidTag is matched, using errors.Is, by every
+// error reporting that a string is not a valid tag.
+var ErrInvalidTag = errors.New("invalid tag")
+
+// TagError reports that a string is not a valid tag. Its message is
+// only formatted when Error is called, so rejecting large numbers of
+// malformed tags is cheap.
+type TagError struct {
+	// Tag holds the string that is not a valid tag.
+	Tag string
+
+	// Kind holds the kind of tag the string was expected to be,
+	// or is empty if the string does not have a valid kind. Where
+	// tags of more than one kind are accepted, it describes them
+	// all, as in "machine or unit".
+	Kind string
+}
+
+// Error implements error.
+func (e *TagError) Error() string {
+	if e.Kind != "" {
+		return strconv.Quote(e.Tag) + " is not a valid " + e.Kind + " tag"
+	}
+	return strconv.Quote(e.Tag) + " is not a valid tag"
+}
+
+// Is reports whether target is ErrInvalidTag.
+func (e *TagError) Is(target error) bool {
+	return target == ErrInvalidTag
+}
+
+func invalidTagError(tag, kind string) error {
+	return &TagError{Tag: tag, Kind: kind}
+}
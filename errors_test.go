@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type errorsSuite struct{}
+
+var _ = gc.Suite(&errorsSuite{})
+
+func (s *errorsSuite) TestTagError(c *gc.C) {
+	_, err := names.ParseTag("unit-foo")
+	c.Assert(err, gc.ErrorMatches, `"unit-foo" is not a valid unit tag`)
+	c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+	tagErr, ok := err.(*names.TagError)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(tagErr.Tag, gc.Equals, "unit-foo")
+	c.Check(tagErr.Kind, gc.Equals, names.UnitTagKind)
+
+	_, err = names.ParseTag("foo")
+	c.Assert(err, gc.ErrorMatches, `"foo" is not a valid tag`)
+	c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+
+	_, err = names.ParseMachineTag("unit-foo-0")
+	c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+
+	wrapped := fmt.Errorf("cannot parse: %w", err)
+	c.Check(errors.Is(wrapped, names.ErrInvalidTag), jc.IsTrue)
+	c.Check(errors.Is(errors.New("other"), names.ErrInvalidTag), jc.IsFalse)
+}
+
+func (s *errorsSuite) TestParseTagFailureAllocations(c *gc.C) {
+	for _, tag := range []string{"unit-foo", "foo-bar", ""} {
+		allocs := testing.AllocsPerRun(100, func() {
+			names.ParseTag(tag)
+		})
+		c.Check(allocs <= 1, jc.IsTrue, gc.Commentf("%q: %v allocations", tag, allocs))
+	}
+}
+
+func BenchmarkParseTagInvalid(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		names.ParseTag("unit-foo")
+	}
+}
+
+func BenchmarkParseTagInvalidKind(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		names.ParseTag("foo-bar")
+	}
+}
@@ -4,3 +4,5 @@
 package names
 
 var InvalidTagError = invalidTagError
+
+var NewLazyRegexp = newLazyRegexp
@@ -5,23 +5,27 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
 const FilesystemTagKind = "filesystem"
 
 // Filesystems may be bound to a machine, meaning that the filesystem cannot
-// exist without that machine. We encode this in the tag.
-var validFilesystem = regexp.MustCompile("^(" + MachineSnippet + "/)?" + NumberSnippet + "$")
+// exist without that machine. We encode this in the tag to allow the
+// filesystem to be identified with its machine, and removed with it.
+// Filesystems on Kubernetes models may likewise be bound to a unit.
+var validFilesystem = newLazyRegexp("^(" + MachineSnippet + "/|" + ServiceSnippet + "/" + NumberSnippet + "/)?" + NumberSnippet + "$")
 
 type FilesystemTag struct {
 	id string
 }
 
-func (t FilesystemTag) String() string { return t.Kind() + "-" + t.id }
-func (t FilesystemTag) Kind() string   { return FilesystemTagKind }
-func (t FilesystemTag) Id() string     { return filesystemTagSuffixToId(t.id) }
+func (t FilesystemTag) String() string      { return t.Kind() + "-" + t.id }
+func (t FilesystemTag) Kind() string        { return FilesystemTagKind }
+func (t FilesystemTag) Id() string          { return filesystemTagSuffixToId(t.id) }
+func (t FilesystemTag) Validate() error     { return validateTag(t) }
+func (t FilesystemTag) PathSegment() string { return pathSegment(t) }
 
 // NewFilesystemTag returns the tag for the filesystem with the given name.
 // It will panic if the given filesystem name is not valid.
@@ -48,7 +52,7 @@ func ParseFilesystemTag(filesystemTag string) (FilesystemTag, error) {
 
 // IsValidFilesystem returns whether id is a valid filesystem id.
 func IsValidFilesystem(id string) bool {
-	return validFilesystem.MatchString(id)
+	return isValidScopedStorageId(validFilesystem, id)
 }
 
 // FilesystemMachine returns the machine component of the filesystem
@@ -57,12 +61,41 @@ func IsValidFilesystem(id string) bool {
 func FilesystemMachine(tag FilesystemTag) (MachineTag, bool) {
 	id := tag.Id()
 	pos := strings.LastIndex(id, "/")
-	if pos == -1 {
+	if pos == -1 || isUnitScopedStorageId(id) {
 		return MachineTag{}, false
 	}
 	return NewMachineTag(id[:pos]), true
 }
 
+// Unit returns the unit the filesystem is bound to,
+// and whether the filesystem is bound to a unit.
+func (t FilesystemTag) Unit() (UnitTag, bool) {
+	return scopedStorageUnit(t.Id())
+}
+
+// SortKey returns a key by which filesystem tags may be ordered
+// naturally: unbound filesystems first, then filesystems bound to machines,
+// ordered as by MachineTag.SortKey, then filesystems bound to units,
+// ordered as by UnitTag.SortKey; filesystems with the same scope are
+// ordered numerically. The key is only meaningful for comparison
+// with other keys.
+func (t FilesystemTag) SortKey() string {
+	return scopedStorageSortKey(t.Id())
+}
+
+// NewUnitFilesystemTag returns the tag for filesystem number n
+// bound to the given unit.
+func NewUnitFilesystemTag(unit UnitTag, n int) (FilesystemTag, error) {
+	if n < 0 {
+		return FilesystemTag{}, fmt.Errorf("%d is not a valid filesystem number", n)
+	}
+	tag, ok := tagFromFilesystemId(unit.Id() + "/" + strconv.Itoa(n))
+	if !ok {
+		return FilesystemTag{}, fmt.Errorf("%q is not a valid unit name", unit.Id())
+	}
+	return tag, nil
+}
+
 func tagFromFilesystemId(id string) (FilesystemTag, bool) {
 	if !IsValidFilesystem(id) {
 		return FilesystemTag{}, false
@@ -72,5 +105,5 @@ func tagFromFilesystemId(id string) (FilesystemTag, bool) {
 }
 
 func filesystemTagSuffixToId(s string) string {
-	return strings.Replace(s, "-", "/", -1)
+	return scopedStorageTagSuffixToId(s)
 }
@@ -27,13 +27,20 @@ func (s *filesystemSuite) TestFilesystemIdValidity(c *gc.C) {
 	assertFilesystemIdInvalid(c, "")
 	assertFilesystemIdInvalid(c, "one")
 	assertFilesystemIdInvalid(c, "#")
-	assertFilesystemIdInvalid(c, "0/0/0") // 0/0 is not a valid machine ID
+	assertFilesystemIdInvalid(c, "0/0/0")     // 0/0 is not a valid machine ID
+	assertFilesystemIdInvalid(c, "0/foo/1/2") // foo is not a known container type
+	assertFilesystemIdValid(c, "mariadb/0/2")
+	assertFilesystemIdValid(c, "my-db/10/0")
+	assertFilesystemIdInvalid(c, "mariadb/2")  // mariadb is not a valid machine ID
+	assertFilesystemIdInvalid(c, "mariadb/0/") // missing filesystem number
 }
 
 func (s *filesystemSuite) TestParseFilesystemTag(c *gc.C) {
 	assertParseFilesystemTag(c, "filesystem-0", names.NewFilesystemTag("0"))
 	assertParseFilesystemTag(c, "filesystem-88", names.NewFilesystemTag("88"))
 	assertParseFilesystemTag(c, "filesystem-0-lxc-0-88", names.NewFilesystemTag("0/lxc/0/88"))
+	assertParseFilesystemTag(c, "filesystem-mariadb-0-2", names.NewFilesystemTag("mariadb/0/2"))
+	assertParseFilesystemTag(c, "filesystem-my-db-10-0", names.NewFilesystemTag("my-db/10/0"))
 	assertParseFilesystemTagInvalid(c, "", names.InvalidTagError("", ""))
 	assertParseFilesystemTagInvalid(c, "one", names.InvalidTagError("one", ""))
 	assertParseFilesystemTagInvalid(c, "filesystem-", names.InvalidTagError("filesystem-", names.FilesystemTagKind))
@@ -44,6 +51,30 @@ func (s *filesystemSuite) TestFilesystemMachine(c *gc.C) {
 	assertFilesystemMachine(c, "0/0", names.NewMachineTag("0"))
 	assertFilesystemMachine(c, "0/lxc/0/0", names.NewMachineTag("0/lxc/0"))
 	assertFilesystemNoMachine(c, "0")
+	assertFilesystemNoMachine(c, "mariadb/0/2")
+}
+
+func (s *filesystemSuite) TestFilesystemUnit(c *gc.C) {
+	unit, ok := names.NewFilesystemTag("my-db/10/2").Unit()
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(unit, gc.Equals, names.NewUnitTag("my-db/10"))
+
+	_, ok = names.NewFilesystemTag("0/2").Unit()
+	c.Assert(ok, gc.Equals, false)
+	_, ok = names.NewFilesystemTag("2").Unit()
+	c.Assert(ok, gc.Equals, false)
+}
+
+func (s *filesystemSuite) TestNewUnitFilesystemTag(c *gc.C) {
+	tag, err := names.NewUnitFilesystemTag(names.NewUnitTag("mariadb/0"), 2)
+	c.Assert(err, gc.IsNil)
+	c.Assert(tag, gc.Equals, names.NewFilesystemTag("mariadb/0/2"))
+	c.Assert(tag.String(), gc.Equals, "filesystem-mariadb-0-2")
+
+	_, err = names.NewUnitFilesystemTag(names.NewUnitTag("mariadb/0"), -1)
+	c.Assert(err, gc.ErrorMatches, `-1 is not a valid filesystem number`)
+	_, err = names.NewUnitFilesystemTag(names.UnitTag{}, 1)
+	c.Assert(err, gc.ErrorMatches, `"" is not a valid unit name`)
 }
 
 func assertFilesystemMachine(c *gc.C, id string, expect names.MachineTag) {
@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build go1.18
+
+package names_test
+
+import (
+	"testing"
+
+	"github.com/juju/names"
+)
+
+// The fuzz targets below check that arbitrary input, such as may
+// arrive from untrusted API clients, never makes the parsers panic,
+// and that whatever they accept survives a round trip through its
+// string form unchanged.
+
+func FuzzParseTag(f *testing.F) {
+	for _, test := range benchTags {
+		f.Add(test.tag)
+	}
+	for _, test := range parseTagTests {
+		f.Add(test.tag)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		tag, err := names.ParseTag(s)
+		if err != nil {
+			return
+		}
+		checkTagRoundTrip(t, s, tag)
+	})
+}
+
+func FuzzParseTagList(f *testing.F) {
+	f.Add(`machine-0, unit-mysql-0 "user-bob@local"`)
+	f.Add(`'service-wordpress`)
+	f.Add("")
+	f.Fuzz(func(t *testing.T, s string) {
+		tags, err := names.ParseTagList(s)
+		if err != nil {
+			return
+		}
+		for _, tag := range tags {
+			checkTagRoundTrip(t, s, tag)
+		}
+	})
+}
+
+func FuzzParseRelationKey(f *testing.F) {
+	f.Add("wordpress:db mysql:server")
+	f.Add("riak:ring")
+	f.Add("wordpress:db mysql:server extra:x")
+	f.Fuzz(func(t *testing.T, key string) {
+		if !names.IsValidRelation(key) {
+			return
+		}
+		tag := names.NewRelationTag(key)
+		if tag.Id() != key {
+			t.Fatalf("relation key %q became %q", key, tag.Id())
+		}
+		checkTagRoundTrip(t, key, tag)
+	})
+}
+
+func FuzzParseMachineId(f *testing.F) {
+	f.Add("0")
+	f.Add("0/lxc/1")
+	f.Add("1/lxd/0/kvm/2")
+	f.Add("01/lxc")
+	f.Fuzz(func(t *testing.T, id string) {
+		valid := names.IsValidMachine(id)
+		if err := names.ValidateMachineId(id); (err == nil) != valid {
+			t.Fatalf("IsValidMachine(%q) is %v but ValidateMachineId returned %v", id, valid, err)
+		}
+		if !valid {
+			return
+		}
+		tag := names.NewMachineTag(id)
+		if tag.Id() != id {
+			t.Fatalf("machine id %q became %q", id, tag.Id())
+		}
+		checkTagRoundTrip(t, id, tag)
+	})
+}
+
+func FuzzParseUnitName(f *testing.F) {
+	f.Add("mysql/0")
+	f.Add("rabbitmq-server/123")
+	f.Add("wordpress-1/0")
+	f.Fuzz(func(t *testing.T, name string) {
+		service, err := names.UnitService(name)
+		if valid := names.IsValidUnit(name); (err == nil) != valid {
+			t.Fatalf("IsValidUnit(%q) is %v but UnitService returned %v", name, valid, err)
+		}
+		if err != nil {
+			return
+		}
+		if !names.IsValidService(service) {
+			t.Fatalf("unit %q has invalid service %q", name, service)
+		}
+		tag := names.NewUnitTag(name)
+		if tag.Id() != name {
+			t.Fatalf("unit name %q became %q", name, tag.Id())
+		}
+		checkTagRoundTrip(t, name, tag)
+	})
+}
+
+func FuzzParseCharmTag(f *testing.F) {
+	f.Add("charm-cs:trusty/mysql-42")
+	f.Add("charm-local:mysql")
+	f.Add("charm-cs:~user/precise/wordpress-1")
+	f.Fuzz(func(t *testing.T, s string) {
+		tag, err := names.ParseCharmTag(s)
+		if err != nil {
+			return
+		}
+		checkTagRoundTrip(t, s, tag)
+		// The accessors must cope with anything that parsed.
+		tag.Schema()
+		tag.User()
+		tag.Name()
+		tag.Series()
+		tag.Revision()
+	})
+}
+
+func FuzzParseChannel(f *testing.F) {
+	f.Add("stable")
+	f.Add("2.0/edge/fix")
+	f.Add("latest/stable")
+	f.Fuzz(func(t *testing.T, s string) {
+		ch, err := names.ParseChannel(s)
+		if err != nil {
+			return
+		}
+		again, err := names.ParseChannel(ch.String())
+		if err != nil {
+			t.Fatalf("channel %q: cannot reparse %q: %v", s, ch.String(), err)
+		}
+		if again != ch {
+			t.Fatalf("channel %q: reparsing %q gave %#v, want %#v", s, ch.String(), again, ch)
+		}
+	})
+}
+
+// checkTagRoundTrip checks that tag, obtained from input, parses
+// back from its string form to the same tag.
+func checkTagRoundTrip(t *testing.T, input string, tag names.Tag) {
+	s := tag.String()
+	again, err := names.ParseTag(s)
+	if err != nil {
+		t.Fatalf("%q: cannot reparse %q: %v", input, s, err)
+	}
+	if again != tag {
+		t.Fatalf("%q: reparsing %q gave %#v, want %#v", input, s, again, tag)
+	}
+	if again.String() != s {
+		t.Fatalf("%q: reparsing %q gave string %q", input, s, again.String())
+	}
+}
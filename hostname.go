@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// MaxHostnameLength holds the maximum length of a hostname
+// returned by MachineHostname, that of a single DNS label.
+const MaxHostnameLength = 63
+
+// hostnameHashLen holds the number of hex digits of the machine id
+// hash used in truncated hostnames.
+const hostnameHashLen = 8
+
+// MachineHostname returns the hostname given to the instance of the
+// machine with the given tag in the given model. It has the form
+// "juju-<short-model>-<machine>", where <short-model> is the
+// model's ShortId and <machine> is the machine id
+// with "/" replaced by "-", e.g. "juju-f47ac1-0-lxd-1".
+//
+// The result consists only of lower case letters, digits and
+// hyphens, and is unique within the model. If it would be longer
+// than MaxHostnameLength, the machine component is replaced by
+// "h<hash>" followed by as many of the innermost "<type>-<number>"
+// container levels as fit, where <hash> is the first 8 hex digits
+// of the SHA-256 hash of the machine id, e.g.
+// "juju-f47ac1-h1a2b3c4d-lxd-8-lxd-9". The hash keeps the names of
+// machines sharing their innermost levels distinct, and its leading
+// "h" keeps them distinct from untruncated names, which start with
+// a machine number.
+func MachineHostname(modelTag ModelTag, machineTag MachineTag) string {
+	prefix := "juju-" + modelTag.ShortId() + "-"
+	id := machineTag.Id()
+	machine := strings.Replace(id, "/", "-", -1)
+	if len(prefix)+len(machine) <= MaxHostnameLength {
+		return prefix + machine
+	}
+	sum := sha256.Sum256([]byte(id))
+	hostname := prefix + "h" + hex.EncodeToString(sum[:])[:hostnameHashLen]
+	// Keep as many whole container levels as fit,
+	// from the innermost one outwards.
+	parts := strings.Split(id, "/")
+	var levels string
+	for i := len(parts) - 2; i >= 1; i -= 2 {
+		level := "-" + parts[i] + "-" + parts[i+1]
+		if len(hostname)+len(level)+len(levels) > MaxHostnameLength {
+			break
+		}
+		levels = level + levels
+	}
+	return hostname + levels
+}
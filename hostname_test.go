@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"fmt"
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type hostnameSuite struct{}
+
+var _ = gc.Suite(&hostnameSuite{})
+
+var hostnameModel = names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+
+func (s *hostnameSuite) TestMachineHostname(c *gc.C) {
+	for i, test := range []struct {
+		machine string
+		expect  string
+	}{
+		{machine: "0", expect: "juju-f47ac1-0"},
+		{machine: "42", expect: "juju-f47ac1-42"},
+		{machine: "0/lxd/1", expect: "juju-f47ac1-0-lxd-1"},
+		{machine: "0/lxd/1/kvm/2", expect: "juju-f47ac1-0-lxd-1-kvm-2"},
+	} {
+		c.Logf("test %d: %q", i, test.machine)
+		hostname := names.MachineHostname(hostnameModel, names.NewMachineTag(test.machine))
+		c.Check(hostname, gc.Equals, test.expect)
+	}
+}
+
+func (s *hostnameSuite) TestMachineHostnameTruncated(c *gc.C) {
+	id := "1" + strings.Repeat("/lxd/123", 10)
+	hostname := names.MachineHostname(hostnameModel, names.NewMachineTag(id))
+	c.Assert(len(hostname) <= names.MaxHostnameLength, gc.Equals, true)
+	c.Assert(hostname, gc.Matches, "juju-f47ac1-h[0-9a-f]{8}-lxd-123-lxd-123-lxd-123-lxd-123-lxd-123")
+}
+
+func (s *hostnameSuite) TestMachineHostnameTruncatedUnique(c *gc.C) {
+	// Machines whose ids differ only in their outer levels
+	// share the kept innermost levels, but not their hashes.
+	var levels string
+	for i := 1; i <= 9; i++ {
+		levels += fmt.Sprintf("/lxd/%d", i)
+	}
+	seen := make(map[string]string)
+	for _, id := range []string{
+		"0" + levels,
+		"1" + levels,
+		"0/kvm/0" + levels,
+		"10" + levels,
+	} {
+		hostname := names.MachineHostname(hostnameModel, names.NewMachineTag(id))
+		c.Logf("%s: %s", id, hostname)
+		c.Check(len(hostname) <= names.MaxHostnameLength, gc.Equals, true)
+		c.Check(hostname, gc.Matches, `juju-f47ac1-h[0-9a-f]{8}(-lxd-[1-9])+`)
+		c.Check(strings.HasSuffix(hostname, "-lxd-8-lxd-9"), gc.Equals, true)
+		c.Check(seen[hostname], gc.Equals, "", gc.Commentf("%q and %q have the same hostname", seen[hostname], id))
+		seen[hostname] = id
+	}
+
+	// Truncated names never clash with untruncated ones,
+	// which start with a machine number.
+	hostname := names.MachineHostname(hostnameModel, names.NewMachineTag("0"+levels))
+	c.Check(hostname[len("juju-f47ac1-"):][0], gc.Equals, byte('h'))
+}
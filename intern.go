@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"reflect"
+	"sync"
+)
+
+// internKey identifies a tag by its kind and string form.
+type internKey struct {
+	kind, tag string
+}
+
+// interned holds the tags returned by Intern.
+var interned = struct {
+	sync.Mutex
+	tags map[internKey]Tag
+}{
+	tags: make(map[internKey]Tag),
+}
+
+// Intern returns a shared instance of the given tag: the first tag
+// passed to Intern that is equal to it (see Equal) and of the same
+// type. Programs that hold on to many copies of the same tags, such
+// as an API server parsing the same machine and unit tags over and
+// over, can use it to reduce memory use.
+//
+// Interned tags are never released, so Intern should only be used
+// for tags drawn from a bounded set.
+func Intern(tag Tag) Tag {
+	if isNilTag(tag) {
+		return tag
+	}
+	key := internKey{kind: tag.Kind(), tag: tag.String()}
+	interned.Lock()
+	defer interned.Unlock()
+	if shared, ok := interned.tags[key]; ok {
+		if reflect.TypeOf(shared) == reflect.TypeOf(tag) {
+			return shared
+		}
+		return tag
+	}
+	interned.tags[key] = tag
+	return tag
+}
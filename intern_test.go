@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type internSuite struct{}
+
+var _ = gc.Suite(&internSuite{})
+
+func (s *internSuite) TestIntern(c *gc.C) {
+	c.Check(names.Intern(nil), gc.IsNil)
+
+	tag := names.Intern(names.NewUnitTag("mysql/0"))
+	c.Check(tag, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Check(names.Intern(names.NewUnitTag("mysql/0")), gc.Equals, tag)
+	c.Check(names.Intern(names.NewMachineTag("0")), gc.Equals, names.NewMachineTag("0"))
+}
+
+// sliceTag is a tag whose type cannot be compared with ==.
+type sliceTag []string
+
+func (t sliceTag) String() string { return t.Kind() + "-" + t.Id() }
+func (t sliceTag) Kind() string   { return "slice" }
+func (t sliceTag) Id() string     { return strings.Join(t, ".") }
+
+func (s *internSuite) TestInternOtherTags(c *gc.C) {
+	tag := names.Intern(sliceTag{"a", "b"})
+	c.Check(names.Intern(sliceTag{"a", "b"}), jc.DeepEquals, tag)
+
+	// Tags of other types are not replaced by
+	// interned tags with the same string.
+	names.Intern(names.NewMachineTag("42"))
+	c.Check(names.Intern(otherTag{"machine", "42"}), gc.Equals, otherTag{"machine", "42"})
+
+	var nilMachine *names.MachineTag
+	c.Check(names.Intern(nilMachine), gc.Equals, nilMachine)
+}
+
+func (s *internSuite) TestInterningParser(c *gc.C) {
+	p := names.Parser{Intern: true}
+	tag1, err := p.ParseTag("unit-wordpress-2")
+	c.Assert(err, jc.ErrorIsNil)
+	tag2, err := p.ParseTag("unit-wordpress-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tag1, gc.Equals, names.NewUnitTag("wordpress/2"))
+	c.Check(tag2, gc.Equals, tag1)
+
+	_, err = p.ParseTag("unit-wordpress")
+	c.Check(err, gc.ErrorMatches, `"unit-wordpress" is not a valid unit tag`)
+}
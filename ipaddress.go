@@ -4,30 +4,72 @@
 package names
 
 import (
+	"fmt"
+	"net"
+
 	"github.com/juju/utils"
 )
 
 const IPAddressTagKind = "ipaddress"
 
-// IsValidIPAddress returns whether id is a valid IP address ID.
-// Here it simply is checked if it is a valid UUID.
+// IsValidIPAddress returns whether id is a valid IP address ID,
+// either a UUID or a literal IPv4 or IPv6 address.
 func IsValidIPAddress(id string) bool {
-	return utils.IsValidUUIDString(id)
+	return isIPAddressLiteral(id) || IsValidUUID(id)
+}
+
+// isIPAddressLiteral returns whether id is an IPv4 or IPv6 address
+// written in canonical form, so that it survives being parsed and
+// formatted again unchanged.
+func isIPAddressLiteral(id string) bool {
+	ip := net.ParseIP(id)
+	return ip != nil && ip.String() == id
 }
 
+// IPAddressTag represents a tag used to describe an IP address,
+// identified either by the UUID of its state document or by the
+// address itself.
 type IPAddressTag struct {
 	id utils.UUID
+	ip string
 }
 
-func (t IPAddressTag) String() string { return t.Kind() + "-" + t.id.String() }
+func (t IPAddressTag) String() string { return t.Kind() + "-" + t.Id() }
 func (t IPAddressTag) Kind() string   { return IPAddressTagKind }
-func (t IPAddressTag) Id() string     { return t.id.String() }
+func (t IPAddressTag) Id() string {
+	if t.IsIP() {
+		return t.ip
+	}
+	return t.id.String()
+}
+func (t IPAddressTag) Validate() error     { return validateTag(t) }
+func (t IPAddressTag) PathSegment() string { return pathSegment(t) }
+
+// IsIP returns whether the tag identifies the address
+// by the address itself rather than by UUID.
+func (t IPAddressTag) IsIP() bool {
+	return t.ip != ""
+}
 
-// NewIPAddressTag returns the tag for the IP address with the given ID (UUID).
+// Value returns the address identifying the tag,
+// or nil if the tag identifies the address by UUID.
+func (t IPAddressTag) Value() net.IP {
+	if !t.IsIP() {
+		return nil
+	}
+	return net.ParseIP(t.ip)
+}
+
+// NewIPAddressTag returns the tag for the IP address with the given
+// ID, which may be a UUID or a literal IPv4 or IPv6 address. UUIDs
+// in upper or mixed case are converted to lower case.
 func NewIPAddressTag(id string) IPAddressTag {
-	uuid, err := utils.UUIDFromString(id)
-	if err != nil {
-		panic(err)
+	if isIPAddressLiteral(id) {
+		return IPAddressTag{ip: id}
+	}
+	uuid, ok := uuidFromString(lowerUUID(id))
+	if !ok {
+		panic(fmt.Errorf("invalid UUID: %q", id))
 	}
 	return IPAddressTag{id: uuid}
 }
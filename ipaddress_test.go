@@ -4,6 +4,9 @@
 package names_test
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
@@ -27,6 +30,43 @@ func (s *ipAddressSuite) TestNewIPAddressTag(c *gc.C) {
 		tag = names.NewIPAddressTag("42")
 	}
 	c.Assert(f, gc.PanicMatches, `invalid UUID: "42"`)
+
+	tag = names.NewIPAddressTag(strings.ToUpper(uuid.String()))
+	c.Assert(tag.Id(), gc.Equals, uuid.String())
+}
+
+func (s *ipAddressSuite) TestIPAddressTagForms(c *gc.C) {
+	for i, test := range []struct {
+		id   string
+		isIP bool
+	}{
+		{"10.0.0.1", true},
+		{"2001:db8::1", true},
+		{"::1", true},
+		{"42424242-1111-2222-3333-0123456789ab", false},
+	} {
+		c.Logf("test %d: %s", i, test.id)
+		tag := names.NewIPAddressTag(test.id)
+		c.Check(tag.IsIP(), gc.Equals, test.isIP)
+		c.Check(tag.Id(), gc.Equals, test.id)
+		c.Check(tag.String(), gc.Equals, names.IPAddressTagKind+"-"+test.id)
+		c.Check(names.IsValidIPAddress(test.id), gc.Equals, true)
+	}
+	c.Check(names.IsValidIPAddress("::ffff:10.0.0.1"), gc.Equals, false)
+	c.Check(names.IsValidIPAddress("10.0.0.256"), gc.Equals, false)
+}
+
+func (s *ipAddressSuite) TestIPAddressTagValue(c *gc.C) {
+	ip := names.NewIPAddressTag("10.0.0.1").Value()
+	c.Check(ip.Equal(net.IPv4(10, 0, 0, 1)), gc.Equals, true)
+	c.Check(ip.To4(), gc.NotNil)
+
+	ip = names.NewIPAddressTag("2001:db8::1").Value()
+	c.Check(ip.Equal(net.ParseIP("2001:db8::1")), gc.Equals, true)
+	c.Check(ip.To4(), gc.IsNil)
+
+	ip = names.NewIPAddressTag("42424242-1111-2222-3333-0123456789ab").Value()
+	c.Check(ip, gc.IsNil)
 }
 
 var parseIPAddressTagTests = []struct {
@@ -36,8 +76,14 @@ var parseIPAddressTagTests = []struct {
 }{
 	{tag: "", err: names.InvalidTagError("", "")},
 	{tag: "ipaddress-42424242-1111-2222-3333-0123456789ab", expected: names.NewIPAddressTag("42424242-1111-2222-3333-0123456789ab")},
+	{tag: "ipaddress-42424242-1111-2222-3333-0123456789AB", expected: names.NewIPAddressTag("42424242-1111-2222-3333-0123456789ab")},
 	{tag: "ipaddress-012345678", err: names.InvalidTagError("ipaddress-012345678", names.IPAddressTagKind)},
 	{tag: "ipaddress-42", err: names.InvalidTagError("ipaddress-42", names.IPAddressTagKind)},
+	{tag: "ipaddress-10.0.0.1", expected: names.NewIPAddressTag("10.0.0.1")},
+	{tag: "ipaddress-2001:db8::1", expected: names.NewIPAddressTag("2001:db8::1")},
+	{tag: "ipaddress-2001:DB8::1", err: names.InvalidTagError("ipaddress-2001:DB8::1", names.IPAddressTagKind)},
+	{tag: "ipaddress-fe80::1%eth0", err: names.InvalidTagError("ipaddress-fe80::1%eth0", names.IPAddressTagKind)},
+	{tag: "ipaddress-10.0.0.0/8", err: names.InvalidTagError("ipaddress-10.0.0.0/8", names.IPAddressTagKind)},
 	{tag: "foobar", err: names.InvalidTagError("foobar", "")},
 	{tag: "space-yadda", err: names.InvalidTagError("space-yadda", names.IPAddressTagKind)}}
 
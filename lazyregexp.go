@@ -0,0 +1,42 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"regexp"
+	"sync"
+)
+
+// lazyRegexp is a regular expression that is compiled the first time
+// it is used rather than at package initialisation, so that programs
+// pay only for the validation rules they need.
+type lazyRegexp struct {
+	expr string
+	once sync.Once
+	re   *regexp.Regexp
+}
+
+// newLazyRegexp returns a regular expression that will be compiled
+// from expr on first use. Like regexp.MustCompile, using it panics if
+// expr cannot be compiled.
+func newLazyRegexp(expr string) *lazyRegexp {
+	return &lazyRegexp{expr: expr}
+}
+
+func (r *lazyRegexp) regexp() *regexp.Regexp {
+	r.once.Do(func() {
+		r.re = regexp.MustCompile(r.expr)
+	})
+	return r.re
+}
+
+// MatchString is like regexp.Regexp.MatchString.
+func (r *lazyRegexp) MatchString(s string) bool {
+	return r.regexp().MatchString(s)
+}
+
+// FindStringSubmatch is like regexp.Regexp.FindStringSubmatch.
+func (r *lazyRegexp) FindStringSubmatch(s string) []string {
+	return r.regexp().FindStringSubmatch(s)
+}
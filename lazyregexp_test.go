@@ -0,0 +1,29 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type lazyRegexpSuite struct{}
+
+var _ = gc.Suite(&lazyRegexpSuite{})
+
+func (s *lazyRegexpSuite) TestMatch(c *gc.C) {
+	re := names.NewLazyRegexp("^(a+)(b*)$")
+	c.Check(re.MatchString("aab"), jc.IsTrue)
+	c.Check(re.MatchString("ba"), jc.IsFalse)
+	c.Check(re.FindStringSubmatch("aab"), jc.DeepEquals, []string{"aab", "aa", "b"})
+	c.Check(re.FindStringSubmatch("ba"), gc.IsNil)
+}
+
+func (s *lazyRegexpSuite) TestCompiledOnFirstUse(c *gc.C) {
+	// An invalid expression is only reported when first used.
+	re := names.NewLazyRegexp("(")
+	c.Check(func() { re.MatchString("") }, gc.PanicMatches, "regexp: Compile.*")
+}
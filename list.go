@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// ParseTagList parses a list of tag strings separated by commas
+// and/or white space, as found in environment variables and command
+// line flags. Each tag may be enclosed in single or double quotes.
+// An empty list results in no tags.
+func ParseTagList(s string) ([]Tag, error) {
+	fields, err := splitTagList(s)
+	if err != nil {
+		return nil, err
+	}
+	var tags []Tag
+	for _, field := range fields {
+		tag, err := ParseTag(field)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// splitTagList splits s into its comma or space separated fields,
+// removing any quotes.
+func splitTagList(s string) ([]string, error) {
+	var fields []string
+	var field []rune
+	var quote rune
+	inField := false
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			field = append(field, r)
+		case r == '"' || r == '\'':
+			quote = r
+			inField = true
+		case r == ',' || unicode.IsSpace(r):
+			if inField {
+				fields = append(fields, string(field))
+				field, inField = field[:0], false
+			}
+		default:
+			field = append(field, r)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("tag list %q has unterminated quote", s)
+	}
+	if inField {
+		fields = append(fields, string(field))
+	}
+	return fields, nil
+}
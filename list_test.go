@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type listSuite struct{}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) TestParseTagList(c *gc.C) {
+	for i, test := range []struct {
+		list   string
+		expect []names.Tag
+		err    string
+	}{{
+		list: "",
+	}, {
+		list: " , ",
+	}, {
+		list:   "machine-0",
+		expect: []names.Tag{names.NewMachineTag("0")},
+	}, {
+		list: "machine-0,unit-mysql-0",
+		expect: []names.Tag{
+			names.NewMachineTag("0"),
+			names.NewUnitTag("mysql/0"),
+		},
+	}, {
+		list: " machine-0, unit-mysql-0\tservice-mysql\n",
+		expect: []names.Tag{
+			names.NewMachineTag("0"),
+			names.NewUnitTag("mysql/0"),
+			names.NewServiceTag("mysql"),
+		},
+	}, {
+		list: `"machine-0",'unit-mysql-0' "relation-wordpress.db#mysql.server"`,
+		expect: []names.Tag{
+			names.NewMachineTag("0"),
+			names.NewUnitTag("mysql/0"),
+			names.NewRelationTag("wordpress:db mysql:server"),
+		},
+	}, {
+		list: `machine-0 "unit-mysql-0`,
+		err:  `tag list .* has unterminated quote`,
+	}, {
+		list: "machine-0,foo",
+		err:  `"foo" is not a valid tag`,
+	}, {
+		list: `""`,
+		err:  `"" is not a valid tag`,
+	}} {
+		c.Logf("test %d: %q", i, test.list)
+		tags, err := names.ParseTagList(test.list)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tags, gc.IsNil)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tags, jc.DeepEquals, test.expect)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build go1.21
+
+package names
+
+import (
+	"log/slog"
+)
+
+// Keys of the structured logging fields emitted for tags.
+const (
+	LogKindKey = "kind"
+	LogIdKey   = "id"
+)
+
+// tagLogValue returns a group value holding the kind and id of tag,
+// or an empty value if tag is nil. The id is redacted if
+// redaction is enabled (see SetRedactSensitiveTags).
+func tagLogValue(tag Tag) slog.Value {
+	if isNilTag(tag) {
+		return slog.Value{}
+	}
+	return slog.GroupValue(
+		slog.String(LogKindKey, tag.Kind()),
+		slog.String(LogIdKey, displayId(tag)),
+	)
+}
+
+// LogValue implements slog.LogValuer.
+func (t ActionTag) LogValue() slog.Value               { return tagLogValue(t) }
+func (t CharmTag) LogValue() slog.Value                { return tagLogValue(t) }
+func (t ControllerTag) LogValue() slog.Value           { return tagLogValue(t) }
+func (t DiscontinuedTag) LogValue() slog.Value         { return tagLogValue(t) }
+func (t EnvironTag) LogValue() slog.Value              { return tagLogValue(t) }
+func (t FilesystemTag) LogValue() slog.Value           { return tagLogValue(t) }
+func (t FilesystemAttachmentTag) LogValue() slog.Value { return tagLogValue(t) }
+func (t IPAddressTag) LogValue() slog.Value            { return tagLogValue(t) }
+func (t MachineTag) LogValue() slog.Value              { return tagLogValue(t) }
+func (t ModelTag) LogValue() slog.Value                { return tagLogValue(t) }
+func (t PayloadTag) LogValue() slog.Value              { return tagLogValue(t) }
+func (t RelationTag) LogValue() slog.Value             { return tagLogValue(t) }
+func (t ServiceTag) LogValue() slog.Value              { return tagLogValue(t) }
+func (t SpaceTag) LogValue() slog.Value                { return tagLogValue(t) }
+func (t StorageTag) LogValue() slog.Value              { return tagLogValue(t) }
+func (t SubnetTag) LogValue() slog.Value               { return tagLogValue(t) }
+func (t UnitTag) LogValue() slog.Value                 { return tagLogValue(t) }
+func (t UserTag) LogValue() slog.Value                 { return tagLogValue(t) }
+func (t VolumeTag) LogValue() slog.Value               { return tagLogValue(t) }
+func (t VolumeAttachmentTag) LogValue() slog.Value     { return tagLogValue(t) }
+
+// LogAttr returns an slog attribute with the given key holding the
+// kind and id of tag. It is useful for tags held in a Tag interface
+// value, which may be nil.
+func LogAttr(key string, tag Tag) slog.Attr {
+	return slog.Attr{Key: key, Value: tagLogValue(tag)}
+}
+
+// FieldEncoder is the subset of zapcore.ObjectEncoder used by
+// MarshalLogFields, so that this package need not depend on zap.
+type FieldEncoder interface {
+	AddString(key, value string)
+}
+
+// MarshalLogFields adds the kind and id of tag to enc, using the
+// same keys and redaction as LogValue. It is intended for implementing
+// zapcore.ObjectMarshaler on types that wrap tags:
+//
+//	func (e entity) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+//		return names.MarshalLogFields(enc, e.tag)
+//	}
+//
+// Nothing is added if tag is nil.
+func MarshalLogFields(enc FieldEncoder, tag Tag) error {
+	if isNilTag(tag) {
+		return nil
+	}
+	enc.AddString(LogKindKey, tag.Kind())
+	enc.AddString(LogIdKey, displayId(tag))
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build go1.21
+
+package names_test
+
+import (
+	"bytes"
+	"log/slog"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type loggingSuite struct{}
+
+var _ = gc.Suite(&loggingSuite{})
+
+var logTags = []names.Tag{
+	names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	names.NewCharmTag("cs:trusty/mysql-1"),
+	names.NewEnvironTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	names.NewFilesystemTag("0/1"),
+	names.NewIPAddressTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	names.NewMachineTag("0/lxc/1"),
+	names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	names.NewPayloadTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	names.NewRelationTag("wordpress:db mysql:server"),
+	names.NewServiceTag("mysql"),
+	names.NewSpaceTag("dmz"),
+	names.NewStorageTag("data/0"),
+	names.NewSubnetTag("10.0.0.0/24"),
+	names.NewControllerTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+	names.NewUnitTag("mysql/0"),
+	names.NewUserTag("bob@remote"),
+	names.NewVolumeTag("0/1"),
+	names.NewVolumeAttachmentTag(names.NewMachineTag("0"), names.NewVolumeTag("0/1")),
+	names.NewFilesystemAttachmentTag(names.NewMachineTag("0"), names.NewFilesystemTag("0/1")),
+}
+
+func (s *loggingSuite) TestLogValue(c *gc.C) {
+	for i, tag := range logTags {
+		c.Logf("test %d: %s", i, tag)
+		valuer, ok := tag.(slog.LogValuer)
+		c.Assert(ok, jc.IsTrue)
+		attrs := valuer.LogValue().Group()
+		c.Assert(attrs, gc.HasLen, 2)
+		c.Check(attrs[0].Key, gc.Equals, names.LogKindKey)
+		c.Check(attrs[0].Value.String(), gc.Equals, tag.Kind())
+		c.Check(attrs[1].Key, gc.Equals, names.LogIdKey)
+		c.Check(attrs[1].Value.String(), gc.Equals, tag.Id())
+	}
+}
+
+func (s *loggingSuite) TestLogValueHandler(c *gc.C) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("started", "entity", names.NewUnitTag("mysql/0"))
+	c.Assert(buf.String(), gc.Equals, "level=INFO msg=started entity.kind=unit entity.id=mysql/0\n")
+}
+
+func (s *loggingSuite) TestLogAttr(c *gc.C) {
+	attr := names.LogAttr("entity", names.NewMachineTag("0"))
+	c.Assert(attr.Key, gc.Equals, "entity")
+	c.Assert(attr.Value.Kind(), gc.Equals, slog.KindGroup)
+	c.Assert(attr.Value.Group(), jc.DeepEquals, []slog.Attr{
+		slog.String(names.LogKindKey, "machine"),
+		slog.String(names.LogIdKey, "0"),
+	})
+
+	attr = names.LogAttr("entity", nil)
+	c.Assert(attr.Value.Any(), gc.IsNil)
+}
+
+func (s *loggingSuite) TestLogRedaction(c *gc.C) {
+	defer names.SetRedactSensitiveTags(names.RedactSensitiveTags())
+	tag := names.NewUserTag("bob@example.com")
+
+	names.SetRedactSensitiveTags(false)
+	c.Assert(tag.LogValue().Group()[1].Value.String(), gc.Equals, "bob@example.com")
+	enc := make(mapEncoder)
+	names.MarshalLogFields(enc, tag)
+	c.Assert(enc[names.LogIdKey], gc.Equals, "bob@example.com")
+
+	names.SetRedactSensitiveTags(true)
+	c.Assert(tag.LogValue().Group()[1].Value.String(), gc.Equals, "bob@***")
+	enc = make(mapEncoder)
+	names.MarshalLogFields(enc, tag)
+	c.Assert(enc[names.LogIdKey], gc.Equals, "bob@***")
+}
+
+type mapEncoder map[string]string
+
+func (e mapEncoder) AddString(key, value string) {
+	e[key] = value
+}
+
+func (s *loggingSuite) TestMarshalLogFields(c *gc.C) {
+	for i, tag := range logTags {
+		c.Logf("test %d: %s", i, tag)
+		enc := make(mapEncoder)
+		err := names.MarshalLogFields(enc, tag)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(enc, jc.DeepEquals, mapEncoder{
+			names.LogKindKey: tag.Kind(),
+			names.LogIdKey:   tag.Id(),
+		})
+	}
+
+	enc := make(mapEncoder)
+	err := names.MarshalLogFields(enc, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enc, gc.HasLen, 0)
+}
@@ -4,7 +4,8 @@
 package names
 
 import (
-	"regexp"
+	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -16,30 +17,211 @@ const (
 	MachineSnippet       = NumberSnippet + "(?:" + ContainerSnippet + ")*"
 )
 
-var validMachine = regexp.MustCompile("^" + MachineSnippet + "$")
+var (
+	validNumber        = newLazyRegexp("^" + NumberSnippet + "$")
+	validContainerType = newLazyRegexp("^" + ContainerTypeSnippet + "$")
+)
+
+// MachineIdRules holds the rules that machine ids must satisfy
+// beyond their basic syntax.
+type MachineIdRules struct {
+	// MaxNestingLevel holds the maximum number of container
+	// levels in a machine id. Zero means there is no limit.
+	MaxNestingLevel int
+
+	// ContainerTypes holds the container types that may appear
+	// in a machine id. If it is empty, any syntactically valid
+	// container type is allowed.
+	ContainerTypes []string
+}
+
+// defaultMachineIdRules holds the rules applied by IsValidMachine
+// and ValidateMachineId. They allow the container types juju
+// supports, and do not limit nesting. They must not be modified.
+var defaultMachineIdRules = MachineIdRules{
+	ContainerTypes: []string{"lxc", "lxd", "kvm"},
+}
+
+// DefaultMachineIdRules returns the rules applied by IsValidMachine
+// and ValidateMachineId, and so by ParseTag. They allow the lxc, lxd
+// and kvm container types, and have no maximum nesting level.
+// Callers with other requirements may modify the result and use it
+// directly or through Parser.MachineIdRules.
+func DefaultMachineIdRules() MachineIdRules {
+	rules := defaultMachineIdRules
+	rules.ContainerTypes = append([]string(nil), rules.ContainerTypes...)
+	return rules
+}
+
+// Validate returns an error describing why id is not a valid
+// machine id under the rules, or nil if it is valid.
+func (r MachineIdRules) Validate(id string) error {
+	parts := strings.Split(id, "/")
+	if !validNumber.MatchString(parts[0]) {
+		return fmt.Errorf("%q is not a valid machine id: invalid machine number %q", id, parts[0])
+	}
+	if len(parts)%2 == 0 {
+		return fmt.Errorf("%q is not a valid machine id: container type %q has no number", id, parts[len(parts)-1])
+	}
+	for i := 1; i < len(parts); i += 2 {
+		if err := r.validateContainerType(parts[i]); err != nil {
+			return fmt.Errorf("%q is not a valid machine id: %v", id, err)
+		}
+		if !validNumber.MatchString(parts[i+1]) {
+			return fmt.Errorf("%q is not a valid machine id: invalid container number %q", id, parts[i+1])
+		}
+	}
+	if level := len(parts) / 2; r.MaxNestingLevel > 0 && level > r.MaxNestingLevel {
+		return fmt.Errorf("%q is not a valid machine id: nesting level %d exceeds maximum of %d", id, level, r.MaxNestingLevel)
+	}
+	return nil
+}
 
-// IsValidMachine returns whether id is a valid machine id.
+// IsValid returns whether id is a valid machine id under the rules.
+// It is equivalent to, but much cheaper than, checking whether
+// Validate returns nil.
+func (r MachineIdRules) IsValid(id string) bool {
+	return r.scanMachineId(id)
+}
+
+func (r MachineIdRules) validateContainerType(containerType string) error {
+	if !validContainerType.MatchString(containerType) {
+		return fmt.Errorf("invalid container type %q", containerType)
+	}
+	if len(r.ContainerTypes) == 0 {
+		return nil
+	}
+	for _, t := range r.ContainerTypes {
+		if t == containerType {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown container type %q", containerType)
+}
+
+// ValidateMachineId returns an error describing why id is not a
+// valid machine id under the rules returned by DefaultMachineIdRules,
+// or nil if it is valid.
+func ValidateMachineId(id string) error {
+	return defaultMachineIdRules.Validate(id)
+}
+
+// IsValidMachine returns whether id is a valid machine id
+// under the rules returned by DefaultMachineIdRules.
 func IsValidMachine(id string) bool {
-	return validMachine.MatchString(id)
+	return defaultMachineIdRules.IsValid(id)
 }
 
 // IsContainerMachine returns whether id is a valid container machine id.
 func IsContainerMachine(id string) bool {
-	return validMachine.MatchString(id) && strings.Contains(id, "/")
+	return IsValidMachine(id) && strings.Contains(id, "/")
 }
 
+// MachineTag represents a tag used to describe a machine. It holds
+// its complete tag string, so that String does not need to build it.
 type MachineTag struct {
-	id string
+	tag string
 }
 
-func (t MachineTag) String() string { return t.Kind() + "-" + t.id }
-func (t MachineTag) Kind() string   { return MachineTagKind }
-func (t MachineTag) Id() string     { return machineTagSuffixToId(t.id) }
+func (t MachineTag) String() string {
+	if t.tag == "" {
+		return t.Kind() + "-"
+	}
+	return t.tag
+}
+
+func (t MachineTag) Kind() string        { return MachineTagKind }
+func (t MachineTag) Id() string          { return machineTagSuffixToId(t.suffix()) }
+func (t MachineTag) Validate() error     { return validateTag(t) }
+func (t MachineTag) PathSegment() string { return pathSegment(t) }
+
+// suffix returns the part of the tag string following the kind.
+func (t MachineTag) suffix() string {
+	if t.tag == "" {
+		return ""
+	}
+	return t.tag[len(MachineTagKind)+1:]
+}
+
+// Parent returns the tag of the machine hosting the container
+// with this tag, and whether there is one. Only container
+// machines have a parent.
+func (t MachineTag) Parent() (MachineTag, bool) {
+	parts := strings.Split(t.Id(), "/")
+	if len(parts) < 3 {
+		return MachineTag{}, false
+	}
+	return NewMachineTag(strings.Join(parts[:len(parts)-2], "/")), true
+}
+
+// ContainerType returns the type of the container with this tag
+// (e.g. "lxc" for machine 0/lxc/1), or "" if the tag is not
+// that of a container.
+func (t MachineTag) ContainerType() string {
+	parts := strings.Split(t.Id(), "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
+
+// NestingLevel returns the number of container levels in the
+// machine id: zero for machines that are not containers, one for
+// containers hosted directly by such a machine, and so on.
+func (t MachineTag) NestingLevel() int {
+	return strings.Count(t.Id(), "/") / 2
+}
+
+// IsContainer returns whether the tag is that of a container.
+func (t MachineTag) IsContainer() bool {
+	return strings.Contains(t.Id(), "/")
+}
+
+// ChildId returns the final, numeric, component of the machine id:
+// the container number within its parent for containers (e.g. "1"
+// for machine 0/lxc/1) or the whole id for other machines.
+func (t MachineTag) ChildId() string {
+	id := t.Id()
+	return id[strings.LastIndex(id, "/")+1:]
+}
+
+// SortKey returns a key by which machine tags may be ordered
+// naturally: machine 2 comes before machine 10, and containers come
+// after their host, ordered level by level by container type and
+// number. The key is only meaningful for comparison with other keys.
+func (t MachineTag) SortKey() string {
+	parts := strings.Split(t.Id(), "/")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = sortKeyNumber(parts[i])
+	}
+	return strings.Join(parts, "/")
+}
 
 // NewMachineTag returns the tag for the machine with the given id.
 func NewMachineTag(id string) MachineTag {
-	id = strings.Replace(id, "/", "-", -1)
-	return MachineTag{id: id}
+	if id == "" {
+		return MachineTag{}
+	}
+	return MachineTag{tag: MachineTagKind + "-" + strings.Replace(id, "/", "-", -1)}
+}
+
+// NewMachineTagFromParts returns the tag for container number n of
+// the given type hosted by the parent machine.
+func NewMachineTagFromParts(parent MachineTag, containerType string, n int) (MachineTag, error) {
+	if !IsValidMachine(parent.Id()) {
+		return MachineTag{}, fmt.Errorf("%q is not a valid parent machine id", parent.Id())
+	}
+	if err := defaultMachineIdRules.validateContainerType(containerType); err != nil {
+		return MachineTag{}, err
+	}
+	if n < 0 {
+		return MachineTag{}, fmt.Errorf("%d is not a valid container number", n)
+	}
+	id := parent.Id() + "/" + containerType + "/" + strconv.Itoa(n)
+	if err := ValidateMachineId(id); err != nil {
+		return MachineTag{}, err
+	}
+	return NewMachineTag(id), nil
 }
 
 // ParseMachineTag parses a machine tag string.
@@ -55,6 +237,12 @@ func ParseMachineTag(machineTag string) (MachineTag, error) {
 	return mt, nil
 }
 
+// MachineIdFromTagString returns the id of the machine with the
+// given tag string, e.g. "0/lxd/1" for "machine-0-lxd-1".
+func MachineIdFromTagString(s string) (string, error) {
+	return idFromTagString(MachineTagKind, s)
+}
+
 func machineTagSuffixToId(s string) string {
 	return strings.Replace(s, "-", "/", -1)
 }
@@ -25,6 +25,21 @@ func (s *machineSuite) TestMachineTag(c *gc.C) {
 	c.Assert(names.NewMachineTag("10/lxc/1").String(), gc.Equals, "machine-10-lxc-1")
 }
 
+func (s *machineSuite) TestZeroMachineTag(c *gc.C) {
+	var tag names.MachineTag
+	c.Assert(tag.String(), gc.Equals, "machine-")
+	c.Assert(tag.Id(), gc.Equals, "")
+	c.Assert(names.NewMachineTag(""), gc.Equals, tag)
+}
+
+func BenchmarkMachineTagString(b *stdtesting.B) {
+	tag := names.NewMachineTag("0/lxd/12")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = tag.String()
+	}
+}
+
 var machineIdTests = []struct {
 	pattern   string
 	valid     bool
@@ -51,6 +66,8 @@ var machineIdTests = []struct {
 	{pattern: "6/lxc/042/kvm/0", valid: false},
 	{pattern: "6/lxc/42/kvm/00", valid: false},
 	{pattern: "06/lxc/042/kvm/00", valid: false},
+	{pattern: "7/lxd/0", valid: true, container: true},
+	{pattern: "8/docker/0", valid: false},
 }
 
 func (s *machineSuite) TestMachineIdFormats(c *gc.C) {
@@ -94,3 +111,139 @@ func (s *machineSuite) TestParseMachineTag(c *gc.C) {
 		c.Check(got, gc.Equals, t.expected)
 	}
 }
+
+func (s *machineSuite) TestMachineTagHierarchy(c *gc.C) {
+	for i, test := range []struct {
+		id            string
+		parent        string
+		containerType string
+		childId       string
+	}{
+		{id: "0", childId: "0"},
+		{id: "42", childId: "42"},
+		{id: "0/lxc/3", parent: "0", containerType: "lxc", childId: "3"},
+		{id: "0/lxc/3/kvm/1", parent: "0/lxc/3", containerType: "kvm", childId: "1"},
+	} {
+		c.Logf("test %d: %q", i, test.id)
+		tag := names.NewMachineTag(test.id)
+		parent, ok := tag.Parent()
+		if test.parent == "" {
+			c.Check(ok, gc.Equals, false)
+			c.Check(parent, gc.Equals, names.MachineTag{})
+		} else {
+			c.Check(ok, gc.Equals, true)
+			c.Check(parent, gc.Equals, names.NewMachineTag(test.parent))
+		}
+		c.Check(tag.ContainerType(), gc.Equals, test.containerType)
+		c.Check(tag.IsContainer(), gc.Equals, test.parent != "")
+		c.Check(tag.ChildId(), gc.Equals, test.childId)
+	}
+}
+
+func (s *machineSuite) TestNewMachineTagFromParts(c *gc.C) {
+	for i, test := range []struct {
+		parent        names.MachineTag
+		containerType string
+		n             int
+		expect        string
+		err           string
+	}{{
+		parent:        names.NewMachineTag("0"),
+		containerType: "lxc",
+		n:             3,
+		expect:        "0/lxc/3",
+	}, {
+		parent:        names.NewMachineTag("0/lxc/3"),
+		containerType: "kvm",
+		n:             0,
+		expect:        "0/lxc/3/kvm/0",
+	}, {
+		parent:        names.MachineTag{},
+		containerType: "lxc",
+		err:           `"" is not a valid parent machine id`,
+	}, {
+		parent:        names.NewMachineTag("0"),
+		containerType: "lxc-nodash",
+		err:           `invalid container type "lxc-nodash"`,
+	}, {
+		parent:        names.NewMachineTag("0"),
+		containerType: "",
+		err:           `invalid container type ""`,
+	}, {
+		parent:        names.NewMachineTag("0"),
+		containerType: "docker",
+		err:           `unknown container type "docker"`,
+	}, {
+		parent:        names.NewMachineTag("0"),
+		containerType: "lxc",
+		n:             -1,
+		err:           `-1 is not a valid container number`,
+	}} {
+		c.Logf("test %d: %v %q %d", i, test.parent, test.containerType, test.n)
+		tag, err := names.NewMachineTagFromParts(test.parent, test.containerType, test.n)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, gc.IsNil)
+		c.Check(tag, gc.Equals, names.NewMachineTag(test.expect))
+		parent, _ := tag.Parent()
+		c.Check(parent, gc.Equals, test.parent)
+	}
+}
+
+func (s *machineSuite) TestMachineTagNestingLevel(c *gc.C) {
+	c.Assert(names.NewMachineTag("0").NestingLevel(), gc.Equals, 0)
+	c.Assert(names.NewMachineTag("0/lxd/1").NestingLevel(), gc.Equals, 1)
+	c.Assert(names.NewMachineTag("0/lxd/1/kvm/2").NestingLevel(), gc.Equals, 2)
+}
+
+func (s *machineSuite) TestValidateMachineId(c *gc.C) {
+	for i, test := range []struct {
+		id  string
+		err string
+	}{
+		{id: "0/lxc/1/kvm/2"},
+		{id: "", err: `"" is not a valid machine id: invalid machine number ""`},
+		{id: "042", err: `"042" is not a valid machine id: invalid machine number "042"`},
+		{id: "0/lxc", err: `"0/lxc" is not a valid machine id: container type "lxc" has no number`},
+		{id: "0/lxc/", err: `"0/lxc/" is not a valid machine id: invalid container number ""`},
+		{id: "0/LXC/1", err: `"0/LXC/1" is not a valid machine id: invalid container type "LXC"`},
+		{id: "0/docker/1", err: `"0/docker/1" is not a valid machine id: unknown container type "docker"`},
+		{id: "0/lxc/01", err: `"0/lxc/01" is not a valid machine id: invalid container number "01"`},
+	} {
+		c.Logf("test %d: %q", i, test.id)
+		err := names.ValidateMachineId(test.id)
+		if test.err == "" {
+			c.Check(err, gc.IsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *machineSuite) TestMachineIdRules(c *gc.C) {
+	rules := names.MachineIdRules{MaxNestingLevel: 1}
+	c.Assert(rules.Validate("0/docker/1"), gc.IsNil)
+	c.Assert(rules.Validate("0/lxd/1/kvm/2"), gc.ErrorMatches,
+		`"0/lxd/1/kvm/2" is not a valid machine id: nesting level 2 exceeds maximum of 1`)
+
+	rules = names.MachineIdRules{ContainerTypes: []string{"lxd"}}
+	c.Assert(rules.Validate("0/lxd/1/lxd/2"), gc.IsNil)
+	c.Assert(rules.Validate("0/lxc/1"), gc.ErrorMatches,
+		`"0/lxc/1" is not a valid machine id: unknown container type "lxc"`)
+}
+
+func (s *machineSuite) TestDefaultMachineIdRules(c *gc.C) {
+	rules := names.DefaultMachineIdRules()
+	c.Assert(rules.ContainerTypes, gc.DeepEquals, []string{"lxc", "lxd", "kvm"})
+
+	// Changing the returned rules does not change those
+	// used by IsValidMachine.
+	rules.ContainerTypes[0] = "docker"
+	rules.ContainerTypes = append(rules.ContainerTypes, "foo")
+	c.Assert(rules.IsValid("0/docker/1"), gc.Equals, true)
+	c.Assert(names.IsValidMachine("0/docker/1"), gc.Equals, false)
+	c.Assert(names.IsValidMachine("0/lxc/1"), gc.Equals, true)
+	c.Assert(names.DefaultMachineIdRules().ContainerTypes, gc.DeepEquals, []string{"lxc", "lxd", "kvm"})
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Matcher matches tags against a regular expression. The expression
+// is anchored at both ends, so it must match the whole of the string
+// it is applied to; which string that is, the tag's string form or
+// its id, is chosen by calling MatchTag or MatchId.
+type Matcher struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// MatchRegexp returns a Matcher for the given regular expression,
+// in the syntax accepted by the regexp package. For example,
+// "unit-mysql-.*" matches the tags of all units of the mysql service
+// with MatchTag, and "mysql/.*" does the same with MatchId.
+func MatchRegexp(pattern string) (Matcher, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return Matcher{}, fmt.Errorf("invalid tag pattern %q: %v", pattern, err)
+	}
+	return Matcher{pattern: pattern, re: re}, nil
+}
+
+// String returns the pattern m was created with.
+func (m Matcher) String() string {
+	return m.pattern
+}
+
+// MatchTag returns whether the pattern matches the
+// string form of tag. Nil tags never match.
+func (m Matcher) MatchTag(tag Tag) bool {
+	return !isNilTag(tag) && m.re.MatchString(tag.String())
+}
+
+// MatchId returns whether the pattern matches the id of tag,
+// whatever its kind. Nil tags never match.
+func (m Matcher) MatchId(tag Tag) bool {
+	return !isNilTag(tag) && m.re.MatchString(tag.Id())
+}
+
+// FilterTags returns the tags for which match returns true, such as
+// the MatchTag or MatchId method of a Matcher, in their original
+// order.
+func FilterTags(tags []Tag, match func(Tag) bool) []Tag {
+	var matched []Tag
+	for _, tag := range tags {
+		if match(tag) {
+			matched = append(matched, tag)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type matchSuite struct{}
+
+var _ = gc.Suite(&matchSuite{})
+
+var matchTags = []names.Tag{
+	names.NewUnitTag("mysql/0"),
+	names.NewUnitTag("mysql/1"),
+	names.NewUnitTag("mysql-router/0"),
+	names.NewServiceTag("mysql"),
+	names.NewMachineTag("0"),
+	names.NewMachineTag("0/lxd/1"),
+	nil,
+}
+
+func (s *matchSuite) TestMatchTag(c *gc.C) {
+	for i, test := range []struct {
+		pattern string
+		expect  []names.Tag
+	}{{
+		pattern: "unit-mysql-.*",
+		expect:  []names.Tag{matchTags[0], matchTags[1], matchTags[2]},
+	}, {
+		pattern: "unit-mysql-[0-9]+",
+		expect:  []names.Tag{matchTags[0], matchTags[1]},
+	}, {
+		pattern: "mysql",
+		expect:  nil,
+	}, {
+		pattern: "machine-0|service-mysql",
+		expect:  []names.Tag{matchTags[3], matchTags[4]},
+	}, {
+		pattern: "machine-0.*",
+		expect:  []names.Tag{matchTags[4], matchTags[5]},
+	}} {
+		c.Logf("test %d: %q", i, test.pattern)
+		m, err := names.MatchRegexp(test.pattern)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(m.String(), gc.Equals, test.pattern)
+		c.Check(names.FilterTags(matchTags, m.MatchTag), jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *matchSuite) TestMatchId(c *gc.C) {
+	for i, test := range []struct {
+		pattern string
+		expect  []names.Tag
+	}{{
+		pattern: "mysql/.*",
+		expect:  []names.Tag{matchTags[0], matchTags[1]},
+	}, {
+		pattern: "mysql",
+		expect:  []names.Tag{matchTags[3]},
+	}, {
+		pattern: "unit-mysql-.*",
+		expect:  nil,
+	}, {
+		pattern: "0(/.*)?",
+		expect:  []names.Tag{matchTags[4], matchTags[5]},
+	}} {
+		c.Logf("test %d: %q", i, test.pattern)
+		m, err := names.MatchRegexp(test.pattern)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(names.FilterTags(matchTags, m.MatchId), jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *matchSuite) TestMatchRegexpError(c *gc.C) {
+	_, err := names.MatchRegexp("unit-(")
+	c.Assert(err, gc.ErrorMatches, `invalid tag pattern "unit-\(": .*`)
+}
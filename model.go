@@ -4,7 +4,9 @@
 package names
 
 import (
-	"regexp"
+	"crypto/rand"
+	"fmt"
+	"io"
 )
 
 const ModelTagKind = "model"
@@ -14,11 +16,29 @@ type ModelTag struct {
 	uuid string
 }
 
-var validUUID = regexp.MustCompile(`[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}`)
+var validModelName = newLazyRegexp("^[a-z0-9]+[a-z0-9-]*$")
 
 // NewModelTag returns the tag of an model with the given model UUID.
+// UUIDs in upper or mixed case are converted to lower case.
 func NewModelTag(uuid string) ModelTag {
-	return ModelTag{uuid: uuid}
+	return ModelTag{uuid: lowerUUID(uuid)}
+}
+
+// NewModelTagWithRandomUUID returns the tag of a model
+// with a newly generated random UUID.
+func NewModelTagWithRandomUUID() (ModelTag, error) {
+	return NewModelTagFromSource(rand.Reader)
+}
+
+// NewModelTagFromSource is like NewModelTagWithRandomUUID, but reads
+// the random bytes for the UUID from source. It allows tests to
+// generate predictable tags.
+func NewModelTagFromSource(source io.Reader) (ModelTag, error) {
+	uuid, err := newUUID(source)
+	if err != nil {
+		return ModelTag{}, fmt.Errorf("cannot generate model UUID: %v", err)
+	}
+	return NewModelTag(uuid), nil
 }
 
 // ParseModelTag parses an environ tag string.
@@ -34,11 +54,58 @@ func ParseModelTag(modelTag string) (ModelTag, error) {
 	return et, nil
 }
 
-func (t ModelTag) String() string { return t.Kind() + "-" + t.Id() }
-func (t ModelTag) Kind() string   { return ModelTagKind }
-func (t ModelTag) Id() string     { return t.uuid }
+// ModelIdFromTagString returns the UUID of the model
+// with the given tag string, in lower case.
+func ModelIdFromTagString(s string) (string, error) {
+	return idFromTagString(ModelTagKind, s)
+}
+
+func (t ModelTag) String() string      { return t.Kind() + "-" + t.Id() }
+func (t ModelTag) Kind() string        { return ModelTagKind }
+func (t ModelTag) Id() string          { return t.uuid }
+func (t ModelTag) Validate() error     { return validateTag(t) }
+func (t ModelTag) PathSegment() string { return pathSegment(t) }
+
+// ShortId returns the conventional abbreviation of the model UUID,
+// its first 6 characters, as used in hostnames and for display.
+// Short ids are not unique and must not be used to identify models.
+func (t ModelTag) ShortId() string {
+	if len(t.uuid) < shortModelIdLen {
+		return t.uuid
+	}
+	return t.uuid[:shortModelIdLen]
+}
+
+// shortModelIdLen holds the length of ids returned by ModelTag.ShortId.
+const shortModelIdLen = 6
+
+// ToEnvironTag returns the environ tag with the same UUID,
+// for use with API versions that predate models.
+func (t ModelTag) ToEnvironTag() EnvironTag {
+	return NewEnvironTag(t.uuid)
+}
+
+// AsModelTag returns the model tag equivalent to the given tag,
+// which may be a ModelTag or an EnvironTag, and whether there is one.
+func AsModelTag(tag Tag) (ModelTag, bool) {
+	switch tag := tag.(type) {
+	case ModelTag:
+		return tag, true
+	case EnvironTag:
+		return tag.ToModelTag(), true
+	}
+	return ModelTag{}, false
+}
 
 // IsValidModel returns whether id is a valid model UUID.
 func IsValidModel(id string) bool {
-	return validUUID.MatchString(id)
+	return containsUUID(id)
+}
+
+// IsValidModelName returns whether name is a valid name for a model.
+// Model names, which are distinct from the UUIDs identifying models
+// in tags, consist of lower case letters, digits and hyphens, and
+// must not start with a hyphen.
+func IsValidModelName(name string) bool {
+	return validModelName.MatchString(name)
 }
@@ -4,6 +4,9 @@
 package names_test
 
 import (
+	"bytes"
+
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/names"
@@ -23,6 +26,12 @@ var parseModelTagTests = []struct {
 }, {
 	tag:      "model-f47ac10b-58cc-4372-a567-0e02b2c3d479",
 	expected: names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+}, {
+	tag:      "model-F47AC10B-58CC-4372-A567-0E02B2C3D479",
+	expected: names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+}, {
+	tag:      "model-f47ac10b-58CC-4372-a567-0E02B2C3D479",
+	expected: names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
 }, {
 	tag: "dave",
 	err: names.InvalidTagError("dave", ""),
@@ -47,3 +56,85 @@ func (s *modelSuite) TestParseModelTag(c *gc.C) {
 		c.Check(got, gc.Equals, t.expected)
 	}
 }
+
+func (s *modelSuite) TestModelEnvironConversion(c *gc.C) {
+	const uuid = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	model := names.NewModelTag(uuid)
+	environ := names.NewEnvironTag(uuid)
+	c.Assert(model.ToEnvironTag(), gc.Equals, environ)
+	c.Assert(environ.ToModelTag(), gc.Equals, model)
+
+	for i, test := range []struct {
+		tag    names.Tag
+		expect names.ModelTag
+		ok     bool
+	}{
+		{tag: model, expect: model, ok: true},
+		{tag: environ, expect: model, ok: true},
+		{tag: names.NewMachineTag("0"), ok: false},
+		{tag: nil, ok: false},
+	} {
+		c.Logf("test %d: %v", i, test.tag)
+		got, ok := names.AsModelTag(test.tag)
+		c.Check(ok, gc.Equals, test.ok)
+		c.Check(got, gc.Equals, test.expect)
+	}
+}
+
+func (s *modelSuite) TestNewModelTagLowersUUID(c *gc.C) {
+	tag := names.NewModelTag("F47AC10B-58CC-4372-A567-0E02B2C3D479")
+	c.Assert(tag.Id(), gc.Equals, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c.Assert(tag.String(), gc.Equals, "model-f47ac10b-58cc-4372-a567-0e02b2c3d479")
+}
+
+func (s *modelSuite) TestModelShortId(c *gc.C) {
+	c.Assert(names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479").ShortId(), gc.Equals, "f47ac1")
+	c.Assert(names.ModelTag{}.ShortId(), gc.Equals, "")
+}
+
+func (s *modelSuite) TestNewModelTagWithRandomUUID(c *gc.C) {
+	tag, err := names.NewModelTagWithRandomUUID()
+	c.Assert(err, gc.IsNil)
+	c.Assert(utils.IsValidUUIDString(tag.Id()), gc.Equals, true)
+
+	other, err := names.NewModelTagWithRandomUUID()
+	c.Assert(err, gc.IsNil)
+	c.Assert(other, gc.Not(gc.Equals), tag)
+}
+
+func (s *modelSuite) TestNewModelTagFromSource(c *gc.C) {
+	source := bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))
+	tag, err := names.NewModelTagFromSource(source)
+	c.Assert(err, gc.IsNil)
+	c.Assert(tag.Id(), gc.Equals, "ffffffff-ffff-4fff-bfff-ffffffffffff")
+
+	source = bytes.NewReader(make([]byte, 16))
+	tag, err = names.NewModelTagFromSource(source)
+	c.Assert(err, gc.IsNil)
+	c.Assert(tag.Id(), gc.Equals, "00000000-0000-4000-8000-000000000000")
+
+	_, err = names.NewModelTagFromSource(bytes.NewReader(nil))
+	c.Assert(err, gc.ErrorMatches, "cannot generate model UUID: EOF")
+}
+
+func (s *modelSuite) TestIsValidModelName(c *gc.C) {
+	for i, test := range []struct {
+		name  string
+		valid bool
+	}{
+		{"", false},
+		{"prod", true},
+		{"prod-2", true},
+		{"2prod", true},
+		{"a", true},
+		{"prod-", true},
+		{"-prod", false},
+		{"Prod", false},
+		{"prod_2", false},
+		{"prod 2", false},
+		{"admin/prod", false},
+	} {
+		c.Logf("test %d: %q", i, test.name)
+		c.Check(names.IsValidModelName(test.name), gc.Equals, test.valid)
+	}
+}
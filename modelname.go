@@ -0,0 +1,45 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModelName is the name of a model qualified by its owner,
+// written "<owner>/<model>", e.g. "admin/prod".
+type ModelName struct {
+	// Owner holds the tag of the user owning the model.
+	Owner UserTag
+
+	// Name holds the name of the model, unique among
+	// the models of its owner.
+	Name string
+}
+
+// ParseQualifiedModelName parses an owner-qualified model name.
+func ParseQualifiedModelName(s string) (ModelName, error) {
+	i := strings.Index(s, "/")
+	if i == -1 {
+		return ModelName{}, fmt.Errorf("%q is not a qualified model name: missing owner", s)
+	}
+	owner, name := s[:i], s[i+1:]
+	if !IsValidUser(owner) {
+		return ModelName{}, fmt.Errorf("%q is not a qualified model name: invalid owner %q", s, owner)
+	}
+	if !IsValidModelName(name) {
+		return ModelName{}, fmt.Errorf("%q is not a qualified model name: invalid model name %q", s, name)
+	}
+	return ModelName{
+		Owner: NewUserTag(owner),
+		Name:  name,
+	}, nil
+}
+
+// String returns the qualified model name in the
+// form accepted by ParseQualifiedModelName.
+func (n ModelName) String() string {
+	return n.Owner.Id() + "/" + n.Name
+}
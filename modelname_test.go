@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type modelNameSuite struct{}
+
+var _ = gc.Suite(&modelNameSuite{})
+
+func (s *modelNameSuite) TestParseQualifiedModelName(c *gc.C) {
+	for i, test := range []struct {
+		name   string
+		expect names.ModelName
+		err    string
+	}{{
+		name:   "admin/prod",
+		expect: names.ModelName{Owner: names.NewUserTag("admin"), Name: "prod"},
+	}, {
+		name:   "bob@external/test-1",
+		expect: names.ModelName{Owner: names.NewUserTag("bob@external"), Name: "test-1"},
+	}, {
+		name: "prod",
+		err:  `"prod" is not a qualified model name: missing owner`,
+	}, {
+		name: "/prod",
+		err:  `"/prod" is not a qualified model name: invalid owner ""`,
+	}, {
+		name: "admin/",
+		err:  `"admin/" is not a qualified model name: invalid model name ""`,
+	}, {
+		name: "admin/prod/2",
+		err:  `"admin/prod/2" is not a qualified model name: invalid model name "prod/2"`,
+	}, {
+		name: "admin/Prod",
+		err:  `"admin/Prod" is not a qualified model name: invalid model name "Prod"`,
+	}} {
+		c.Logf("test %d: %q", i, test.name)
+		name, err := names.ParseQualifiedModelName(test.name)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(name, jc.DeepEquals, test.expect)
+		c.Check(name.String(), gc.Equals, test.name)
+	}
+}
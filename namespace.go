@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamespaceKey returns a key for storing data about the given entity
+// of the given model in a store shared between models, such as etcd,
+// S3 or a cache. The key is the model UUID and the tag string joined
+// by sep, e.g. "<model-uuid>/unit-mysql-0".
+//
+// Tag strings may themselves contain sep, but model UUIDs cannot, so
+// keys are unique for each model and tag and can be parsed with
+// ParseNamespaceKey. The separator must therefore be a non-empty
+// string containing no hexadecimal digits or hyphens, such as "/" or
+// ":"; NamespaceKey panics if it is not, or if it occurs in the model
+// UUID.
+func NamespaceKey(modelTag ModelTag, tag Tag, sep string) string {
+	return NamespacePrefix(modelTag, sep) + tag.String()
+}
+
+// NamespacePrefix returns the prefix shared by the keys returned by
+// NamespaceKey for entities of the given model, for use in listing
+// or deleting the data of a model.
+func NamespacePrefix(modelTag ModelTag, sep string) string {
+	uuid := modelTag.Id()
+	if !isValidNamespaceSeparator(sep) || strings.Contains(uuid, sep) {
+		panic(fmt.Sprintf("invalid namespace key separator %q", sep))
+	}
+	return uuid + sep
+}
+
+// isValidNamespaceSeparator returns whether sep may separate the
+// model UUIDs and tags in namespace keys: it must not be empty, and
+// must not contain characters that appear in UUIDs.
+func isValidNamespaceSeparator(sep string) bool {
+	return sep != "" && !strings.ContainsAny(sep, "0123456789abcdefABCDEF-")
+}
+
+// ParseNamespaceKey parses a key returned by NamespaceKey with the
+// same separator, returning the model and entity tags.
+func ParseNamespaceKey(key, sep string) (ModelTag, Tag, error) {
+	if !isValidNamespaceSeparator(sep) {
+		return ModelTag{}, nil, fmt.Errorf("invalid namespace key separator %q", sep)
+	}
+	i := strings.Index(key, sep)
+	if i == -1 {
+		return ModelTag{}, nil, fmt.Errorf("%q is not a valid namespace key", key)
+	}
+	uuid := key[:i]
+	if !IsValidModel(uuid) {
+		return ModelTag{}, nil, fmt.Errorf("%q is not a valid namespace key: invalid model UUID %q", key, uuid)
+	}
+	tag, err := ParseTag(key[i+len(sep):])
+	if err != nil {
+		return ModelTag{}, nil, fmt.Errorf("%q is not a valid namespace key: %v", key, err)
+	}
+	return NewModelTag(uuid), tag, nil
+}
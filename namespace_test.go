@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type namespaceSuite struct{}
+
+var _ = gc.Suite(&namespaceSuite{})
+
+func (s *namespaceSuite) TestNamespaceKey(c *gc.C) {
+	model := names.NewModelTag(docModelUUID)
+	for i, test := range []struct {
+		tag    names.Tag
+		sep    string
+		expect string
+	}{
+		{names.NewUnitTag("mysql/0"), "/", docModelUUID + "/unit-mysql-0"},
+		{names.NewCharmTag("cs:trusty/mysql-1"), "/", docModelUUID + "/charm-cs:trusty/mysql-1"},
+		{names.NewSubnetTag("10.0.0.0/24"), ":", docModelUUID + ":subnet-10.0.0.0/24"},
+		{names.NewMachineTag("0/lxd/1"), "::", docModelUUID + "::machine-0-lxd-1"},
+	} {
+		c.Logf("test %d: %v %q", i, test.tag, test.sep)
+		key := names.NamespaceKey(model, test.tag, test.sep)
+		c.Check(key, gc.Equals, test.expect)
+		c.Check(key[:len(names.NamespacePrefix(model, test.sep))], gc.Equals, names.NamespacePrefix(model, test.sep))
+
+		gotModel, gotTag, err := names.ParseNamespaceKey(key, test.sep)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(gotModel, gc.Equals, model)
+		c.Check(gotTag, gc.Equals, test.tag)
+	}
+}
+
+func (s *namespaceSuite) TestNamespaceKeyRoundTripsCorpus(c *gc.C) {
+	model := names.NewModelTag(docModelUUID)
+	for _, entry := range namestest.Corpus() {
+		if !entry.Valid {
+			continue
+		}
+		tag, err := names.ParseTag(entry.Tag)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, sep := range []string{"/", ":", "|"} {
+			gotModel, gotTag, err := names.ParseNamespaceKey(names.NamespaceKey(model, tag, sep), sep)
+			c.Assert(err, jc.ErrorIsNil, gc.Commentf("%q with %q", entry.Tag, sep))
+			c.Check(gotModel, gc.Equals, model)
+			c.Check(gotTag, jc.DeepEquals, tag)
+		}
+	}
+}
+
+func (s *namespaceSuite) TestNamespaceKeySeparators(c *gc.C) {
+	model := names.NewModelTag(docModelUUID)
+	tag := names.NewMachineTag("0")
+	for i, test := range []struct {
+		sep   string
+		valid bool
+	}{
+		{"/", true},
+		{":", true},
+		{"|", true},
+		{".", true},
+		{"::", true},
+		{"", false},
+		{"-", false},
+		{"_-", false},
+		{"a", false},
+		{"F", false},
+		{"0", false},
+	} {
+		c.Logf("test %d: %q", i, test.sep)
+		if !test.valid {
+			c.Check(func() { names.NamespaceKey(model, tag, test.sep) }, gc.PanicMatches, `invalid namespace key separator ".*"`)
+			c.Check(func() { names.NamespacePrefix(model, test.sep) }, gc.PanicMatches, `invalid namespace key separator ".*"`)
+			_, _, err := names.ParseNamespaceKey(docModelUUID+test.sep+"machine-0", test.sep)
+			c.Check(err, gc.ErrorMatches, `invalid namespace key separator ".*"`)
+			continue
+		}
+		key := names.NamespaceKey(model, tag, test.sep)
+		c.Check(key, gc.Equals, docModelUUID+test.sep+"machine-0")
+		gotModel, gotTag, err := names.ParseNamespaceKey(key, test.sep)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(gotModel, gc.Equals, model)
+		c.Check(gotTag, gc.Equals, tag)
+	}
+}
+
+func (s *namespaceSuite) TestParseNamespaceKeyErrors(c *gc.C) {
+	for i, test := range []struct {
+		key string
+		sep string
+		err string
+	}{
+		{"x/machine-0", "", `invalid namespace key separator ""`},
+		{"machine-0", "/", `"machine-0" is not a valid namespace key`},
+		{"foo/machine-0", "/", `"foo/machine-0" is not a valid namespace key: invalid model UUID "foo"`},
+		{docModelUUID + "/machine-x", "/", `".*/machine-x" is not a valid namespace key: "machine-x" is not a valid machine tag`},
+	} {
+		c.Logf("test %d: %q %q", i, test.key, test.sep)
+		_, _, err := names.ParseNamespaceKey(test.key, test.sep)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
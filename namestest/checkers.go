@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package namestest
+
+import (
+	"fmt"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+// The checkers below accept either tags or tag strings as values.
+// Each has a gocheck version, for use with c.Check and c.Assert, and
+// a quicktest version with a QT prefix, for use with qt.Check and
+// qt.Assert. The latter implement quicktest's Checker interface
+// without this package depending on quicktest.
+
+// IsValidTag checks that the obtained value is a valid tag string,
+// or a tag that survives a round trip through its string form.
+//
+//	c.Check(tag, namestest.IsValidTag)
+var IsValidTag gc.Checker = &tagChecker{
+	CheckerInfo: &gc.CheckerInfo{Name: "IsValidTag", Params: []string{"obtained"}},
+	check:       checkValidTag,
+}
+
+// SameTag checks that the obtained value and the expected value
+// are the same tag, each being given as a tag or a tag string.
+//
+//	c.Check(tag, namestest.SameTag, "machine-0")
+var SameTag gc.Checker = &tagChecker{
+	CheckerInfo: &gc.CheckerInfo{Name: "SameTag", Params: []string{"obtained", "expected"}},
+	check:       checkSameTag,
+}
+
+// TagOfKind returns a checker that checks that the obtained value
+// is a valid tag of the given kind.
+//
+//	c.Check(tag, namestest.TagOfKind(names.UnitTagKind))
+func TagOfKind(kind string) gc.Checker {
+	return &tagChecker{
+		CheckerInfo: &gc.CheckerInfo{Name: "TagOfKind", Params: []string{"obtained"}},
+		check:       tagOfKindCheck(kind),
+	}
+}
+
+// QTIsValidTag is the quicktest version of IsValidTag.
+//
+//	qt.Check(t, tag, namestest.QTIsValidTag)
+var QTIsValidTag = &QTChecker{
+	argNames: []string{"got"},
+	check:    checkValidTag,
+}
+
+// QTSameTag is the quicktest version of SameTag.
+//
+//	qt.Check(t, tag, namestest.QTSameTag, "machine-0")
+var QTSameTag = &QTChecker{
+	argNames: []string{"got", "want"},
+	check:    checkSameTag,
+}
+
+// QTTagOfKind is the quicktest version of TagOfKind.
+//
+//	qt.Check(t, tag, namestest.QTTagOfKind(names.UnitTagKind))
+func QTTagOfKind(kind string) *QTChecker {
+	return &QTChecker{
+		argNames: []string{"got"},
+		check:    tagOfKindCheck(kind),
+	}
+}
+
+type tagChecker struct {
+	*gc.CheckerInfo
+	check func(params []interface{}) error
+}
+
+// Check implements gc.Checker.
+func (c *tagChecker) Check(params []interface{}, names []string) (bool, string) {
+	if err := c.check(params); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+// QTChecker is a checker for tags that implements
+// the Checker interface of quicktest.
+type QTChecker struct {
+	argNames []string
+	check    func(params []interface{}) error
+}
+
+// Check implements quicktest.Checker.
+func (c *QTChecker) Check(got interface{}, args []interface{}, note func(key string, value interface{})) error {
+	return c.check(append([]interface{}{got}, args...))
+}
+
+// ArgNames implements quicktest.Checker.
+func (c *QTChecker) ArgNames() []string {
+	return c.argNames
+}
+
+// toTag returns the tag given by v, which must be a tag or a
+// valid tag string. Tags are checked to survive a round trip
+// through their string form.
+func toTag(v interface{}) (names.Tag, error) {
+	switch v := v.(type) {
+	case string:
+		return names.ParseTag(v)
+	case names.Tag:
+		parsed, err := names.ParseTag(v.String())
+		if err != nil {
+			return nil, err
+		}
+		if parsed != v {
+			return nil, fmt.Errorf("tag %#v parses back as %#v", v, parsed)
+		}
+		return v, nil
+	case nil:
+		return nil, fmt.Errorf("value is nil, not a tag")
+	}
+	return nil, fmt.Errorf("value of type %T is not a tag or string", v)
+}
+
+func checkValidTag(params []interface{}) error {
+	_, err := toTag(params[0])
+	return err
+}
+
+func checkSameTag(params []interface{}) error {
+	obtained, err := toTag(params[0])
+	if err != nil {
+		return fmt.Errorf("obtained: %v", err)
+	}
+	expected, err := toTag(params[1])
+	if err != nil {
+		return fmt.Errorf("expected: %v", err)
+	}
+	if obtained.String() != expected.String() {
+		return fmt.Errorf("tags differ: %q is not %q", obtained, expected)
+	}
+	return nil
+}
+
+func tagOfKindCheck(kind string) func(params []interface{}) error {
+	return func(params []interface{}) error {
+		tag, err := toTag(params[0])
+		if err != nil {
+			return err
+		}
+		if tag.Kind() != kind {
+			return fmt.Errorf("tag %q has kind %q, not %q", tag, tag.Kind(), kind)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package namestest_test
+
+import (
+	"regexp"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type checkersSuite struct{}
+
+var _ = gc.Suite(&checkersSuite{})
+
+var isValidTagTests = []struct {
+	value interface{}
+	err   string
+}{{
+	value: "machine-0",
+}, {
+	value: names.NewUnitTag("mysql/0"),
+}, {
+	value: "machine-01",
+	err:   `"machine-01" is not a valid machine tag`,
+}, {
+	value: names.MachineTag{},
+	err:   `"machine-" is not a valid machine tag`,
+}, {
+	value: 42,
+	err:   `value of type int is not a tag or string`,
+}, {
+	value: nil,
+	err:   `value is nil, not a tag`,
+}}
+
+func (s *checkersSuite) TestIsValidTag(c *gc.C) {
+	for i, test := range isValidTagTests {
+		c.Logf("test %d: %#v", i, test.value)
+		ok, msg := namestest.IsValidTag.Check([]interface{}{test.value}, nil)
+		c.Check(ok, gc.Equals, test.err == "")
+		c.Check(msg, gc.Equals, test.err)
+
+		err := namestest.QTIsValidTag.Check(test.value, nil, nil)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, regexp.QuoteMeta(test.err))
+		}
+	}
+	c.Check("unit-mysql-0", namestest.IsValidTag)
+}
+
+var sameTagTests = []struct {
+	obtained interface{}
+	expected interface{}
+	err      string
+}{{
+	obtained: names.NewMachineTag("0"),
+	expected: "machine-0",
+}, {
+	obtained: "unit-mysql-0",
+	expected: names.NewUnitTag("mysql/0"),
+}, {
+	obtained: names.NewMachineTag("0"),
+	expected: names.NewMachineTag("1"),
+	err:      `tags differ: "machine-0" is not "machine-1"`,
+}, {
+	obtained: "foo",
+	expected: "machine-0",
+	err:      `obtained: "foo" is not a valid tag`,
+}, {
+	obtained: "machine-0",
+	expected: 0,
+	err:      `expected: value of type int is not a tag or string`,
+}}
+
+func (s *checkersSuite) TestSameTag(c *gc.C) {
+	for i, test := range sameTagTests {
+		c.Logf("test %d: %#v %#v", i, test.obtained, test.expected)
+		ok, msg := namestest.SameTag.Check([]interface{}{test.obtained, test.expected}, nil)
+		c.Check(ok, gc.Equals, test.err == "")
+		c.Check(msg, gc.Equals, test.err)
+
+		err := namestest.QTSameTag.Check(test.obtained, []interface{}{test.expected}, nil)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, regexp.QuoteMeta(test.err))
+		}
+	}
+	c.Check(names.NewServiceTag("mysql"), namestest.SameTag, "service-mysql")
+}
+
+func (s *checkersSuite) TestTagOfKind(c *gc.C) {
+	checker := namestest.TagOfKind(names.UnitTagKind)
+	ok, msg := checker.Check([]interface{}{"unit-mysql-0"}, nil)
+	c.Check(ok, jc.IsTrue)
+	c.Check(msg, gc.Equals, "")
+
+	ok, msg = checker.Check([]interface{}{names.NewMachineTag("0")}, nil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(msg, gc.Equals, `tag "machine-0" has kind "machine", not "unit"`)
+
+	ok, msg = checker.Check([]interface{}{"unit-mysql"}, nil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(msg, gc.Equals, `"unit-mysql" is not a valid unit tag`)
+
+	err := namestest.QTTagOfKind(names.MachineTagKind).Check("unit-mysql-0", nil, nil)
+	c.Check(err, gc.ErrorMatches, `tag "unit-mysql-0" has kind "unit", not "machine"`)
+
+	c.Check(names.NewMachineTag("0"), namestest.TagOfKind(names.MachineTagKind))
+}
+
+func (s *checkersSuite) TestInfo(c *gc.C) {
+	c.Check(namestest.IsValidTag.Info().Params, jc.DeepEquals, []string{"obtained"})
+	c.Check(namestest.SameTag.Info().Params, jc.DeepEquals, []string{"obtained", "expected"})
+	c.Check(namestest.QTIsValidTag.ArgNames(), jc.DeepEquals, []string{"got"})
+	c.Check(namestest.QTSameTag.ArgNames(), jc.DeepEquals, []string{"got", "want"})
+	c.Check(namestest.QTTagOfKind("unit").ArgNames(), jc.DeepEquals, []string{"got"})
+}
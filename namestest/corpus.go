@@ -0,0 +1,187 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package namestest
+
+import (
+	"encoding/json"
+
+	"github.com/juju/names"
+)
+
+// CorpusEntry describes a tag string and how it must be parsed.
+type CorpusEntry struct {
+	// Kind holds the kind of the tag. For invalid tags, it
+	// holds the kind the string claims, if any.
+	Kind string `json:"kind,omitempty"`
+
+	// Tag holds the tag string.
+	Tag string `json:"tag"`
+
+	// Id holds the id of a valid tag.
+	Id string `json:"id,omitempty"`
+
+	// String holds the string form of a valid tag, if it differs
+	// from the tag string parsed, as when UUIDs are converted to
+	// lower case.
+	String string `json:"string,omitempty"`
+
+	// Valid holds whether the tag string is valid.
+	Valid bool `json:"valid"`
+}
+
+var corpus = []CorpusEntry{
+	// Valid tag strings.
+	{Kind: "action", Tag: "action-f47ac10b-58cc-4372-a567-0e02b2c3d479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "action", Tag: "action-123", Id: "123", Valid: true},
+	{Kind: "charm", Tag: "charm-cs:trusty/mysql-42", Id: "cs:trusty/mysql-42", Valid: true},
+	{Kind: "charm", Tag: "charm-cs:~user/trusty/mysql", Id: "cs:~user/trusty/mysql", Valid: true},
+	{Kind: "charm", Tag: "charm-local:precise/wordpress", Id: "local:precise/wordpress", Valid: true},
+	{Kind: "charm", Tag: "charm-ch:mysql", Id: "ch:mysql", Valid: true},
+	{Kind: "controller", Tag: "controller-deadbeef-0bad-400d-8000-4b1d0d06f00d", Id: "deadbeef-0bad-400d-8000-4b1d0d06f00d", Valid: true},
+	{Kind: "controller", Tag: "controller-DEADBEEF-0BAD-400D-8000-4B1D0D06F00D", Id: "deadbeef-0bad-400d-8000-4b1d0d06f00d", String: "controller-deadbeef-0bad-400d-8000-4b1d0d06f00d", Valid: true},
+	{Kind: "environment", Tag: "environment-f47ac10b-58cc-4372-a567-0e02b2c3d479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "environment", Tag: "environment-F47ac10b-58CC-4372-a567-0e02b2c3d479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", String: "environment-f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "filesystem", Tag: "filesystem-0", Id: "0", Valid: true},
+	{Kind: "filesystem", Tag: "filesystem-0-1", Id: "0/1", Valid: true},
+	{Kind: "filesystem", Tag: "filesystem-0-lxc-0-1", Id: "0/lxc/0/1", Valid: true},
+	{Kind: "filesystem", Tag: "filesystem-mysql-0-1", Id: "mysql/0/1", Valid: true},
+	{Kind: "filesystemattachment", Tag: "filesystemattachment-0:0-1", Id: "0:0/1", Valid: true},
+	{Kind: "filesystemattachment", Tag: "filesystemattachment-0-lxd-1:mysql-0-2", Id: "0/lxd/1:mysql/0/2", Valid: true},
+	{Kind: "ipaddress", Tag: "ipaddress-f47ac10b-58cc-4372-a567-0e02b2c3d479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "ipaddress", Tag: "ipaddress-F47AC10B-58CC-4372-A567-0E02B2C3D479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", String: "ipaddress-f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "ipaddress", Tag: "ipaddress-10.0.0.1", Id: "10.0.0.1", Valid: true},
+	{Kind: "machine", Tag: "machine-0", Id: "0", Valid: true},
+	{Kind: "machine", Tag: "machine-10-lxc-1", Id: "10/lxc/1", Valid: true},
+	{Kind: "machine", Tag: "machine-1-lxd-2-kvm-3", Id: "1/lxd/2/kvm/3", Valid: true},
+	{Kind: "model", Tag: "model-f47ac10b-58cc-4372-a567-0e02b2c3d479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "model", Tag: "model-F47AC10B-58CC-4372-A567-0E02B2C3D479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", String: "model-f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "payload", Tag: "payload-f47ac10b-58cc-4372-a567-0e02b2c3d479", Id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Valid: true},
+	{Kind: "payload", Tag: "payload-spam", Id: "spam", Valid: true},
+	{Kind: "payload", Tag: "payload-docker/abc123", Id: "docker/abc123", Valid: true},
+	{Kind: "relation", Tag: "relation-wordpress.db#mysql.server", Id: "wordpress:db mysql:server", Valid: true},
+	{Kind: "relation", Tag: "relation-riak.ring", Id: "riak:ring", Valid: true},
+	{Kind: "service", Tag: "service-wordpress", Id: "wordpress", Valid: true},
+	{Kind: "service", Tag: "service-mysql-server", Id: "mysql-server", Valid: true},
+	{Kind: "space", Tag: "space-dmz", Id: "dmz", Valid: true},
+	{Kind: "space", Tag: "space-public-net", Id: "public-net", Valid: true},
+	{Kind: "space", Tag: "space-0", Id: "0", Valid: true},
+	{Kind: "storage", Tag: "storage-data-0", Id: "data/0", Valid: true},
+	{Kind: "storage", Tag: "storage-db-dir-12", Id: "db-dir/12", Valid: true},
+	{Kind: "subnet", Tag: "subnet-10.0.0.0/24", Id: "10.0.0.0/24", Valid: true},
+	{Kind: "subnet", Tag: "subnet-2001:db8::/32", Id: "2001:db8::/32", Valid: true},
+	{Kind: "subnet", Tag: "subnet-7", Id: "7", Valid: true},
+	{Kind: "unit", Tag: "unit-mysql-0", Id: "mysql/0", Valid: true},
+	{Kind: "unit", Tag: "unit-rabbitmq-server-123", Id: "rabbitmq-server/123", Valid: true},
+	{Kind: "user", Tag: "user-bob", Id: "bob", Valid: true},
+	{Kind: "user", Tag: "user-bob@local", Id: "bob@local", Valid: true},
+	{Kind: "user", Tag: "user-bob@example.com", Id: "bob@example.com", Valid: true},
+	{Kind: "volume", Tag: "volume-0", Id: "0", Valid: true},
+	{Kind: "volume", Tag: "volume-0-lxc-0-1", Id: "0/lxc/0/1", Valid: true},
+	{Kind: "volume", Tag: "volume-mysql-0-1", Id: "mysql/0/1", Valid: true},
+	{Kind: "volumeattachment", Tag: "volumeattachment-0:2", Id: "0:2", Valid: true},
+	{Kind: "volumeattachment", Tag: "volumeattachment-1-kvm-0:1-kvm-0-3", Id: "1/kvm/0:1/kvm/0/3", Valid: true},
+	{Tag: ""},
+	{Tag: "machine"},
+	{Tag: "foo-bar"},
+	{Kind: "action", Tag: "action-"},
+	{Kind: "action", Tag: "action-foo"},
+	{Kind: "charm", Tag: "charm-foo:bar"},
+	{Kind: "charm", Tag: "charm-cs:Mysql"},
+	{Kind: "controller", Tag: "controller-foo"},
+	{Kind: "controller", Tag: "controller-xdeadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	{Kind: "environment", Tag: "environment-foo"},
+	{Kind: "filesystem", Tag: "filesystem-a"},
+	{Kind: "filesystem", Tag: "filesystem-0-lxc"},
+	{Kind: "ipaddress", Tag: "ipaddress-foo"},
+	{Kind: "ipaddress", Tag: "ipaddress-010.0.0.1"},
+	{Kind: "machine", Tag: "machine-01"},
+	{Kind: "machine", Tag: "machine--1"},
+	{Kind: "machine", Tag: "machine-0-lxc"},
+	{Kind: "machine", Tag: "machine-0-LXC-1"},
+	{Kind: "model", Tag: "model-foo"},
+	{Kind: "payload", Tag: "payload-1spam"},
+	{Kind: "payload", Tag: "payload-spam/"},
+	{Kind: "relation", Tag: "relation-wordpress#mysql"},
+	{Kind: "relation", Tag: "relation-Wordpress.db"},
+	{Kind: "service", Tag: "service-"},
+	{Kind: "service", Tag: "service-mysql-1"},
+	{Kind: "service", Tag: "service-1mysql"},
+	{Kind: "space", Tag: "space-Dmz"},
+	{Kind: "space", Tag: "space-dmz-"},
+	{Kind: "storage", Tag: "storage-data"},
+	{Kind: "storage", Tag: "storage-data-01"},
+	{Kind: "subnet", Tag: "subnet-10.0.0.0"},
+	{Kind: "subnet", Tag: "subnet-10.0.0.1/24"},
+	{Kind: "unit", Tag: "unit-"},
+	{Kind: "unit", Tag: "unit-mysql"},
+	{Kind: "unit", Tag: "unit-mysql-01"},
+	{Kind: "unit", Tag: "unit-1mysql-0"},
+	{Kind: "user", Tag: "user-b"},
+	{Kind: "user", Tag: "user-bob@"},
+	{Kind: "user", Tag: "user-@local"},
+	{Kind: "volume", Tag: "volume-a"},
+	{Kind: "volume", Tag: "volume-0-lxc"},
+	{Kind: "volumeattachment", Tag: "volumeattachment-0"},
+	{Kind: "volumeattachment", Tag: "volumeattachment-0:a"},
+	{Kind: "volumeattachment", Tag: "volumeattachment-0:1:2"},
+	{Kind: "filesystemattachment", Tag: "filesystemattachment-:0"},
+	{Kind: "filesystemattachment", Tag: "filesystemattachment-mysql-0:0"},
+}
+
+// Corpus returns a list of valid and invalid tag strings of every
+// kind, along with the results of parsing them. The list is fixed:
+// entries are only ever added, so it may be used to check that other
+// implementations of tag parsing, in Go or other languages, agree
+// with this one.
+func Corpus() []CorpusEntry {
+	return append([]CorpusEntry(nil), corpus...)
+}
+
+// CorpusJSON returns the corpus, as returned by Corpus,
+// encoded as a JSON array.
+func CorpusJSON() ([]byte, error) {
+	return json.MarshalIndent(corpus, "", "\t")
+}
+
+// T holds the methods of *testing.T and *gc.C
+// used by VerifyRoundTrips.
+type T interface {
+	Errorf(format string, args ...interface{})
+}
+
+// VerifyRoundTrips checks that every tag string in the corpus is
+// parsed as expected, and that every valid one survives a round trip
+// through its string form, reporting any failures to t.
+func VerifyRoundTrips(t T) {
+	for _, entry := range corpus {
+		tag, err := names.ParseTag(entry.Tag)
+		if !entry.Valid {
+			if err == nil {
+				t.Errorf("tag %q: expected an error, got %#v", entry.Tag, tag)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("tag %q: unexpected error: %v", entry.Tag, err)
+			continue
+		}
+		if kind := tag.Kind(); kind != entry.Kind {
+			t.Errorf("tag %q: got kind %q, want %q", entry.Tag, kind, entry.Kind)
+		}
+		if id := tag.Id(); id != entry.Id {
+			t.Errorf("tag %q: got id %q, want %q", entry.Tag, id, entry.Id)
+		}
+		want := entry.String
+		if want == "" {
+			want = entry.Tag
+		}
+		if s := tag.String(); s != want {
+			t.Errorf("tag %q: got string %q, want %q", entry.Tag, s, want)
+		}
+		again, err := names.ParseTag(tag.String())
+		if err != nil || again != tag {
+			t.Errorf("tag %q: reparsing gave %#v, %v", entry.Tag, again, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package namestest_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	stdtesting "testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names/namestest"
+)
+
+// VerifyRoundTrips must be usable from plain Go tests.
+var _ namestest.T = (*stdtesting.T)(nil)
+
+var updateCorpus = flag.Bool("update-corpus", false, "update testdata/corpus.json")
+
+type corpusSuite struct{}
+
+var _ = gc.Suite(&corpusSuite{})
+
+func (s *corpusSuite) TestVerifyRoundTrips(c *gc.C) {
+	namestest.VerifyRoundTrips(c)
+}
+
+func (s *corpusSuite) TestCoversEveryKind(c *gc.C) {
+	valid := make(map[string]int)
+	invalid := make(map[string]int)
+	for _, entry := range namestest.Corpus() {
+		if entry.Valid {
+			valid[entry.Kind]++
+		} else {
+			invalid[entry.Kind]++
+		}
+	}
+	for _, kind := range namestest.Kinds() {
+		c.Check(valid[kind] > 0, jc.IsTrue, gc.Commentf("no valid %s tags", kind))
+		c.Check(invalid[kind] > 0, jc.IsTrue, gc.Commentf("no invalid %s tags", kind))
+	}
+}
+
+func (s *corpusSuite) TestCorpusIsCopy(c *gc.C) {
+	namestest.Corpus()[0].Tag = "foo"
+	c.Assert(namestest.Corpus()[0].Tag, gc.Not(gc.Equals), "foo")
+}
+
+// TestCorpusFile checks that testdata/corpus.json, which is provided
+// for implementations in other languages, matches the corpus.
+// Run the tests with -update-corpus to update it.
+func (s *corpusSuite) TestCorpusFile(c *gc.C) {
+	data, err := namestest.CorpusJSON()
+	c.Assert(err, jc.ErrorIsNil)
+	data = append(data, '\n')
+	path := filepath.Join("testdata", "corpus.json")
+	if *updateCorpus {
+		c.Assert(ioutil.WriteFile(path, data, 0644), jc.ErrorIsNil)
+	}
+	existing, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(existing), gc.Equals, string(data), gc.Commentf("run the tests with -update-corpus"))
+}
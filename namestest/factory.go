@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package namestest
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/juju/names"
+)
+
+// Factory mints tags for use in tests. The tags it returns are
+// deterministic, depending only on the seed and the order of calls,
+// so tests need not hard code UUIDs, and factories with different
+// seeds, such as the names of different suites, do not mint the same
+// UUIDs. It is safe for concurrent use.
+type Factory struct {
+	seed string
+
+	mu         sync.Mutex
+	uuids      int
+	machines   int
+	containers map[string]int
+	units      map[string]int
+	services   int
+	users      int
+}
+
+// NewFactory returns a factory whose UUIDs are derived from seed.
+func NewFactory(seed string) *Factory {
+	return &Factory{
+		seed:       seed,
+		containers: make(map[string]int),
+		units:      make(map[string]int),
+	}
+}
+
+// UUID returns the next UUID. It is a name-based (version 5) UUID,
+// whose name is made from the seed and the number of UUIDs returned
+// before it.
+func (f *Factory) UUID() string {
+	f.mu.Lock()
+	n := f.uuids
+	f.uuids++
+	f.mu.Unlock()
+
+	h := sha1.New()
+	h.Write(factoryNamespace[:])
+	h.Write([]byte(f.seed + "/" + strconv.Itoa(n)))
+	b := h.Sum(nil)
+	b[6] = b[6]&0x0f | 0x50
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// factoryNamespace holds the namespace of the UUIDs returned by
+// Factory.UUID, itself a random UUID.
+var factoryNamespace = [16]byte{
+	0x8e, 0x5d, 0x7c, 0x39, 0x2b, 0x1a, 0x4f, 0x6e,
+	0x9d, 0x03, 0x51, 0xc4, 0x6a, 0x28, 0xe7, 0x90,
+}
+
+// ModelTag returns the tag of a model with the next UUID.
+func (f *Factory) ModelTag() names.ModelTag {
+	return names.NewModelTag(f.UUID())
+}
+
+// ControllerUUID returns the next UUID, for use as the UUID
+// of a controller.
+func (f *Factory) ControllerUUID() string {
+	return f.UUID()
+}
+
+// MachineTag returns the tag of the next machine, numbered
+// from 0 upwards.
+func (f *Factory) MachineTag() names.MachineTag {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := strconv.Itoa(f.machines)
+	f.machines++
+	return names.NewMachineTag(id)
+}
+
+// ContainerTag returns the tag of the next container of the given
+// type within the parent machine, numbered from 0 upwards. It panics
+// if containerType is not a valid container type.
+func (f *Factory) ContainerTag(parent names.MachineTag, containerType string) names.MachineTag {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := parent.Id() + "/" + containerType
+	tag, err := names.NewMachineTagFromParts(parent, containerType, f.containers[key])
+	if err != nil {
+		panic(err)
+	}
+	f.containers[key]++
+	return tag
+}
+
+// ServiceTag returns the tag of the next service, named
+// "service0", "service1" and so on.
+func (f *Factory) ServiceTag() names.ServiceTag {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := "service" + strconv.Itoa(f.services)
+	f.services++
+	return names.NewServiceTag(name)
+}
+
+// UnitTag returns the tag of the next unit of the given service,
+// numbered from 0 upwards. It panics if service is not a valid
+// service name.
+func (f *Factory) UnitTag(service string) names.UnitTag {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tag, err := names.NewUnitTagFromParts(service, f.units[service])
+	if err != nil {
+		panic(err)
+	}
+	f.units[service]++
+	return tag
+}
+
+// UserTag returns the tag of the next local user, named
+// "user0", "user1" and so on.
+func (f *Factory) UserTag() names.UserTag {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := "user" + strconv.Itoa(f.users)
+	f.users++
+	return names.NewUserTag(name)
+}
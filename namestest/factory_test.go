@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package namestest_test
+
+import (
+	"sync"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type factorySuite struct{}
+
+var _ = gc.Suite(&factorySuite{})
+
+func (s *factorySuite) TestUUIDsAreDeterministic(c *gc.C) {
+	f1 := namestest.NewFactory("factorySuite")
+	f2 := namestest.NewFactory("factorySuite")
+	for i := 0; i < 3; i++ {
+		uuid := f1.UUID()
+		c.Check(names.IsValidUUID(uuid), jc.IsTrue)
+		c.Check(uuid[14], gc.Equals, byte('5'))
+		c.Check(f2.UUID(), gc.Equals, uuid)
+	}
+	// The same UUIDs are minted in every run.
+	c.Check(namestest.NewFactory("factorySuite").UUID(), gc.Equals, "2fb241bc-8d99-5968-ba06-6513da99b96d")
+}
+
+func (s *factorySuite) TestUUIDsDependOnSeed(c *gc.C) {
+	seen := make(map[string]bool)
+	for _, seed := range []string{"a", "b", ""} {
+		f := namestest.NewFactory(seed)
+		for i := 0; i < 100; i++ {
+			uuid := f.UUID()
+			c.Assert(seen[uuid], jc.IsFalse, gc.Commentf("duplicate %s", uuid))
+			seen[uuid] = true
+		}
+	}
+}
+
+func (s *factorySuite) TestModelTag(c *gc.C) {
+	f := namestest.NewFactory("model")
+	tag := f.ModelTag()
+	c.Check(tag, namestest.TagOfKind(names.ModelTagKind))
+	c.Check(f.ModelTag(), gc.Not(gc.Equals), tag)
+	c.Check(names.IsValidUUID(f.ControllerUUID()), jc.IsTrue)
+}
+
+func (s *factorySuite) TestMachineTags(c *gc.C) {
+	f := namestest.NewFactory("machine")
+	m0 := f.MachineTag()
+	c.Check(m0, namestest.SameTag, "machine-0")
+	c.Check(f.MachineTag(), namestest.SameTag, "machine-1")
+	c.Check(f.ContainerTag(m0, "lxd"), namestest.SameTag, "machine-0-lxd-0")
+	c.Check(f.ContainerTag(m0, "lxd"), namestest.SameTag, "machine-0-lxd-1")
+	c.Check(f.ContainerTag(m0, "kvm"), namestest.SameTag, "machine-0-kvm-0")
+	c.Check(func() { f.ContainerTag(m0, "foo") }, gc.PanicMatches, `unknown container type "foo"`)
+}
+
+func (s *factorySuite) TestServiceAndUnitTags(c *gc.C) {
+	f := namestest.NewFactory("unit")
+	c.Check(f.ServiceTag(), namestest.SameTag, "service-service0")
+	c.Check(f.ServiceTag(), namestest.SameTag, "service-service1")
+	c.Check(f.UnitTag("mysql"), namestest.SameTag, "unit-mysql-0")
+	c.Check(f.UnitTag("mysql"), namestest.SameTag, "unit-mysql-1")
+	c.Check(f.UnitTag("wordpress"), namestest.SameTag, "unit-wordpress-0")
+	c.Check(func() { f.UnitTag("1mysql") }, gc.PanicMatches, `"1mysql" is not a valid service name`)
+}
+
+func (s *factorySuite) TestUserTags(c *gc.C) {
+	f := namestest.NewFactory("user")
+	c.Check(f.UserTag(), namestest.SameTag, "user-user0")
+	c.Check(f.UserTag(), namestest.SameTag, "user-user1")
+}
+
+func (s *factorySuite) TestConcurrentUse(c *gc.C) {
+	f := namestest.NewFactory("concurrent")
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				tags := []names.Tag{f.MachineTag(), f.UnitTag("mysql"), f.ModelTag()}
+				mu.Lock()
+				for _, tag := range tags {
+					seen[tag.String()] = true
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	c.Check(seen, gc.HasLen, 300)
+}
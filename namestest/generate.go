@@ -0,0 +1,435 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package namestest provides helpers for testing code that handles
+// names and tags, such as generators of random ids of every kind.
+//
+// The generators take a *rand.Rand, so they may be used directly
+// from property-based tests, and the id types below implement
+// quick.Generator for use with testing/quick.
+package namestest
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/names"
+)
+
+// generator knows how to make random valid ids of one kind,
+// and tags from them.
+type generator struct {
+	id  func(r *rand.Rand) string
+	tag func(id string) names.Tag
+}
+
+var generators = map[string]generator{
+	names.ActionTagKind: {
+		id: func(r *rand.Rand) string {
+			if r.Intn(2) == 0 {
+				return number(r)
+			}
+			return uuid(r)
+		},
+		tag: func(id string) names.Tag { return names.NewActionTag(id) },
+	},
+	names.CharmTagKind: {
+		id:  charmURL,
+		tag: func(id string) names.Tag { return names.NewCharmTag(id) },
+	},
+	names.ControllerTagKind: {
+		id:  uuid,
+		tag: func(id string) names.Tag { return names.NewControllerTag(id) },
+	},
+	names.EnvironTagKind: {
+		id:  uuid,
+		tag: func(id string) names.Tag { return names.NewEnvironTag(id) },
+	},
+	names.FilesystemTagKind: {
+		id:  scopedStorageId,
+		tag: func(id string) names.Tag { return names.NewFilesystemTag(id) },
+	},
+	names.FilesystemAttachmentTagKind: {
+		id: func(r *rand.Rand) string { return machineId(r) + ":" + scopedStorageId(r) },
+		tag: func(id string) names.Tag {
+			machine, filesystem := splitAttachmentId(id)
+			return names.NewFilesystemAttachmentTag(names.NewMachineTag(machine), names.NewFilesystemTag(filesystem))
+		},
+	},
+	names.IPAddressTagKind: {
+		id: func(r *rand.Rand) string {
+			if r.Intn(2) == 0 {
+				return ipv4(r).String()
+			}
+			return uuid(r)
+		},
+		tag: func(id string) names.Tag { return names.NewIPAddressTag(id) },
+	},
+	names.MachineTagKind: {
+		id:  machineId,
+		tag: func(id string) names.Tag { return names.NewMachineTag(id) },
+	},
+	names.ModelTagKind: {
+		id:  uuid,
+		tag: func(id string) names.Tag { return names.NewModelTag(id) },
+	},
+	names.PayloadTagKind: {
+		id: func(r *rand.Rand) string {
+			if r.Intn(2) == 0 {
+				return uuid(r)
+			}
+			class := bounded(r, letters, alnum+"-", alnum)
+			return class + "/" + bounded(r, alnum, alnum+"._-", alnum)
+		},
+		tag: func(id string) names.Tag { return names.NewPayloadTag(id) },
+	},
+	names.RelationTagKind: {
+		id:  relationKey,
+		tag: func(id string) names.Tag { return names.NewRelationTag(id) },
+	},
+	names.ServiceTagKind: {
+		id:  serviceName,
+		tag: func(id string) names.Tag { return names.NewServiceTag(id) },
+	},
+	names.SpaceTagKind: {
+		id:  spaceName,
+		tag: func(id string) names.Tag { return names.NewSpaceTag(id) },
+	},
+	names.StorageTagKind: {
+		id:  func(r *rand.Rand) string { return serviceName(r) + "/" + number(r) },
+		tag: func(id string) names.Tag { return names.NewStorageTag(id) },
+	},
+	names.SubnetTagKind: {
+		id: func(r *rand.Rand) string {
+			if r.Intn(2) == 0 {
+				return number(r)
+			}
+			ip := ipv4(r)
+			mask := net.CIDRMask(8+r.Intn(25), 32)
+			return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+		},
+		tag: func(id string) names.Tag { return names.NewSubnetTag(id) },
+	},
+	names.UnitTagKind: {
+		id:  unitName,
+		tag: func(id string) names.Tag { return names.NewUnitTag(id) },
+	},
+	names.UserTagKind: {
+		id:  userId,
+		tag: func(id string) names.Tag { return names.NewUserTag(id) },
+	},
+	names.VolumeTagKind: {
+		id:  scopedStorageId,
+		tag: func(id string) names.Tag { return names.NewVolumeTag(id) },
+	},
+	names.VolumeAttachmentTagKind: {
+		id: func(r *rand.Rand) string { return machineId(r) + ":" + scopedStorageId(r) },
+		tag: func(id string) names.Tag {
+			machine, volume := splitAttachmentId(id)
+			return names.NewVolumeAttachmentTag(names.NewMachineTag(machine), names.NewVolumeTag(volume))
+		},
+	},
+}
+
+// Kinds returns, in sorted order, the tag kinds for
+// which random ids can be generated.
+func Kinds() []string {
+	kinds := make([]string, 0, len(generators))
+	for kind := range generators {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func generatorFor(kind string) generator {
+	g, ok := generators[kind]
+	if !ok {
+		panic(fmt.Sprintf("no generator for tag kind %q", kind))
+	}
+	return g
+}
+
+// ValidId returns a random id that is valid in tags of the given kind.
+// It panics if kind is not one of Kinds.
+func ValidId(r *rand.Rand, kind string) string {
+	return generatorFor(kind).id(r)
+}
+
+// ValidTag returns a random valid tag of the given kind.
+// It panics if kind is not one of Kinds.
+func ValidTag(r *rand.Rand, kind string) names.Tag {
+	g := generatorFor(kind)
+	return g.tag(g.id(r))
+}
+
+// RandomTag returns a random valid tag of a random kind.
+func RandomTag(r *rand.Rand) names.Tag {
+	kinds := Kinds()
+	return ValidTag(r, kinds[r.Intn(len(kinds))])
+}
+
+// nearValidChars holds the characters used when mutating valid
+// ids. They are those that most often matter to the validators.
+const nearValidChars = "az09AZ-_./:@#~ "
+
+// NearValidId returns a random id of the given kind with a single
+// character inserted, removed or replaced. Such ids are usually, but
+// not always, invalid, which makes them good at finding the edges of
+// the validation rules. It panics if kind is not one of Kinds.
+func NearValidId(r *rand.Rand, kind string) string {
+	return mutate(r, ValidId(r, kind))
+}
+
+// NearValidTagString returns the string form of a random tag of the
+// given kind with a single character inserted, removed or replaced.
+// It panics if kind is not one of Kinds.
+func NearValidTagString(r *rand.Rand, kind string) string {
+	return mutate(r, ValidTag(r, kind).String())
+}
+
+func mutate(r *rand.Rand, s string) string {
+	i := r.Intn(len(s) + 1)
+	c := pick(r, nearValidChars)
+	switch {
+	case i == len(s):
+		return s + c
+	case r.Intn(3) == 0:
+		return s[:i] + c + s[i:]
+	case r.Intn(2) == 0:
+		return s[:i] + s[i+1:]
+	}
+	return s[:i] + c + s[i+1:]
+}
+
+const (
+	lower      = "abcdefghijklmnopqrstuvwxyz"
+	digits     = "0123456789"
+	letters    = lower + "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lowerAlnum = lower + digits
+	alnum      = letters + digits
+)
+
+// chars returns up to max random characters from set.
+func chars(r *rand.Rand, set string, max int) string {
+	b := make([]byte, r.Intn(max+1))
+	for i := range b {
+		b[i] = set[r.Intn(len(set))]
+	}
+	return string(b)
+}
+
+// pick returns a random character from set.
+func pick(r *rand.Rand, set string) string {
+	return string(set[r.Intn(len(set))])
+}
+
+// bounded returns a random string starting with a character from
+// first and, when longer than that, ending with one from last.
+// Characters between are taken from middle.
+func bounded(r *rand.Rand, first, middle, last string) string {
+	s := pick(r, first)
+	if r.Intn(2) == 0 {
+		s += chars(r, middle, 6) + pick(r, last)
+	}
+	return s
+}
+
+func number(r *rand.Rand) string {
+	if r.Intn(4) == 0 {
+		return "0"
+	}
+	return strconv.Itoa(r.Intn(1000) + 1)
+}
+
+func uuid(r *rand.Rand) string {
+	var b [16]byte
+	r.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func ipv4(r *rand.Rand) net.IP {
+	return net.IPv4(byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256))).To4()
+}
+
+// serviceName returns a random service name: words of lower case
+// letters and digits separated by hyphens, each containing a letter
+// and the first starting with one.
+func serviceName(r *rand.Rand) string {
+	s := pick(r, lower) + chars(r, lowerAlnum, 6)
+	for n := r.Intn(3); n > 0; n-- {
+		s += "-" + chars(r, lowerAlnum, 2) + pick(r, lower) + chars(r, lowerAlnum, 2)
+	}
+	return s
+}
+
+func unitName(r *rand.Rand) string {
+	return serviceName(r) + "/" + number(r)
+}
+
+func machineId(r *rand.Rand) string {
+	id := number(r)
+	containerTypes := names.DefaultMachineIdRules().ContainerTypes
+	for n := r.Intn(3); n > 0; n-- {
+		id += "/" + containerTypes[r.Intn(len(containerTypes))] + "/" + number(r)
+	}
+	return id
+}
+
+// scopedStorageId returns a random volume or filesystem id,
+// which may be scoped to a machine or unit.
+func scopedStorageId(r *rand.Rand) string {
+	switch r.Intn(3) {
+	case 0:
+		return machineId(r) + "/" + number(r)
+	case 1:
+		return unitName(r) + "/" + number(r)
+	}
+	return number(r)
+}
+
+// splitAttachmentId splits a volume or filesystem
+// attachment id into its machine and storage ids.
+func splitAttachmentId(id string) (machine, storage string) {
+	i := strings.Index(id, ":")
+	return id[:i], id[i+1:]
+}
+
+func relationName(r *rand.Rand) string {
+	s := pick(r, lower) + chars(r, lowerAlnum, 6)
+	for n := r.Intn(3); n > 0; n-- {
+		s += pick(r, "_-") + pick(r, lowerAlnum) + chars(r, lowerAlnum, 3)
+	}
+	return s
+}
+
+func relationKey(r *rand.Rand) string {
+	key := serviceName(r) + ":" + relationName(r)
+	if r.Intn(4) == 0 {
+		return key
+	}
+	return key + " " + serviceName(r) + ":" + relationName(r)
+}
+
+func spaceName(r *rand.Rand) string {
+	s := pick(r, lowerAlnum) + chars(r, lowerAlnum, 6)
+	for n := r.Intn(3); n > 0; n-- {
+		s += "-" + pick(r, lowerAlnum) + chars(r, lowerAlnum, 3)
+	}
+	return s
+}
+
+// userPart returns a random user name or domain.
+func userPart(r *rand.Rand) string {
+	return pick(r, alnum) + chars(r, alnum+".+-", 8) + pick(r, alnum)
+}
+
+func userId(r *rand.Rand) string {
+	switch r.Intn(3) {
+	case 0:
+		return userPart(r)
+	case 1:
+		return userPart(r) + "@" + names.LocalUserDomain
+	}
+	return userPart(r) + "@" + userPart(r)
+}
+
+func charmURL(r *rand.Rand) string {
+	name := serviceName(r)
+	if r.Intn(2) == 0 {
+		name += "-" + number(r)
+	}
+	series := pick(r, lower) + chars(r, lower, 6)
+	switch r.Intn(4) {
+	case 0:
+		return names.LocalCharmSchema + ":" + series + "/" + name
+	case 1:
+		return names.CharmHubCharmSchema + ":" + name
+	case 2:
+		return names.CharmStoreCharmSchema + ":~" + userPart(r) + "/" + series + "/" + name
+	}
+	return names.CharmStoreCharmSchema + ":" + series + "/" + name
+}
+
+// The following types implement quick.Generator, producing random
+// valid values, so that they may be used as the arguments of
+// functions passed to quick.Check.
+
+// Tag holds a random valid tag of a random kind.
+type Tag struct {
+	names.Tag
+}
+
+// Generate implements quick.Generator.
+func (Tag) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Tag{RandomTag(r)})
+}
+
+// TagString holds the string form of a random tag of a random kind,
+// which is valid except where changed by a single character, as by
+// NearValidTagString.
+type TagString string
+
+// Generate implements quick.Generator.
+func (TagString) Generate(r *rand.Rand, size int) reflect.Value {
+	s := RandomTag(r).String()
+	if r.Intn(2) == 0 {
+		s = mutate(r, s)
+	}
+	return reflect.ValueOf(TagString(s))
+}
+
+// MachineId holds a random valid machine id.
+type MachineId string
+
+// Generate implements quick.Generator.
+func (MachineId) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(MachineId(machineId(r)))
+}
+
+// UnitName holds a random valid unit name.
+type UnitName string
+
+// Generate implements quick.Generator.
+func (UnitName) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(UnitName(unitName(r)))
+}
+
+// ServiceName holds a random valid service name.
+type ServiceName string
+
+// Generate implements quick.Generator.
+func (ServiceName) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(ServiceName(serviceName(r)))
+}
+
+// RelationKey holds a random valid relation key.
+type RelationKey string
+
+// Generate implements quick.Generator.
+func (RelationKey) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(RelationKey(relationKey(r)))
+}
+
+// UserId holds a random valid user id.
+type UserId string
+
+// Generate implements quick.Generator.
+func (UserId) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(UserId(userId(r)))
+}
+
+// UUID holds a random valid UUID.
+type UUID string
+
+// Generate implements quick.Generator.
+func (UUID) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(UUID(uuid(r)))
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package namestest_test
+
+import (
+	"math/rand"
+	"testing/quick"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type generateSuite struct{}
+
+var _ = gc.Suite(&generateSuite{})
+
+var validators = map[string]func(string) bool{
+	names.ActionTagKind:               names.IsValidAction,
+	names.CharmTagKind:                names.IsValidCharm,
+	names.ControllerTagKind:           names.IsValidController,
+	names.EnvironTagKind:              names.IsValidEnvironment,
+	names.FilesystemTagKind:           names.IsValidFilesystem,
+	names.FilesystemAttachmentTagKind: names.IsValidFilesystemAttachment,
+	names.IPAddressTagKind:            names.IsValidIPAddress,
+	names.MachineTagKind:              names.IsValidMachine,
+	names.ModelTagKind:                names.IsValidModel,
+	names.PayloadTagKind: func(id string) bool {
+		// Payload tags also accept UUIDs, for compatibility.
+		return names.IsValidPayload(id) || names.IsValidUUID(id)
+	},
+	names.RelationTagKind: names.IsValidRelation,
+	names.ServiceTagKind:  names.IsValidService,
+	names.SpaceTagKind:    names.IsValidSpace,
+	names.StorageTagKind:  names.IsValidStorage,
+	names.SubnetTagKind: func(id string) bool {
+		return names.IsValidSubnet(id) || names.IsValidSubnetID(id)
+	},
+	names.UnitTagKind:             names.IsValidUnit,
+	names.UserTagKind:             names.IsValidUser,
+	names.VolumeTagKind:           names.IsValidVolume,
+	names.VolumeAttachmentTagKind: names.IsValidVolumeAttachment,
+}
+
+func (s *generateSuite) TestKinds(c *gc.C) {
+	var kinds []string
+	for kind := range validators {
+		kinds = append(kinds, kind)
+	}
+	c.Assert(namestest.Kinds(), jc.SameContents, kinds)
+}
+
+func (s *generateSuite) TestValidId(c *gc.C) {
+	r := rand.New(rand.NewSource(0))
+	for _, kind := range namestest.Kinds() {
+		c.Logf("kind %s", kind)
+		isValid := validators[kind]
+		for i := 0; i < 1000; i++ {
+			id := namestest.ValidId(r, kind)
+			c.Assert(isValid(id), jc.IsTrue, gc.Commentf("%q", id))
+		}
+	}
+}
+
+func (s *generateSuite) TestValidTag(c *gc.C) {
+	r := rand.New(rand.NewSource(0))
+	for _, kind := range namestest.Kinds() {
+		c.Logf("kind %s", kind)
+		for i := 0; i < 1000; i++ {
+			tag := namestest.ValidTag(r, kind)
+			c.Assert(tag.Kind(), gc.Equals, kind)
+			parsed, err := names.ParseTag(tag.String())
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(parsed, gc.Equals, tag)
+		}
+	}
+}
+
+func (s *generateSuite) TestNearValidId(c *gc.C) {
+	r := rand.New(rand.NewSource(0))
+	for _, kind := range namestest.Kinds() {
+		c.Logf("kind %s", kind)
+		isValid := validators[kind]
+		invalid := 0
+		for i := 0; i < 1000; i++ {
+			if !isValid(namestest.NearValidId(r, kind)) {
+				invalid++
+			}
+		}
+		// Most, but not necessarily all, near valid ids are invalid.
+		c.Check(invalid > 100, jc.IsTrue, gc.Commentf("%d invalid", invalid))
+	}
+}
+
+func (s *generateSuite) TestNearValidTagString(c *gc.C) {
+	r := rand.New(rand.NewSource(0))
+	invalid := 0
+	for i := 0; i < 1000; i++ {
+		if _, err := names.ParseTag(namestest.NearValidTagString(r, names.UnitTagKind)); err != nil {
+			invalid++
+		}
+	}
+	c.Check(invalid > 100, jc.IsTrue, gc.Commentf("%d invalid", invalid))
+}
+
+func (s *generateSuite) TestDeterministic(c *gc.C) {
+	for _, kind := range namestest.Kinds() {
+		r1 := rand.New(rand.NewSource(42))
+		r2 := rand.New(rand.NewSource(42))
+		c.Check(namestest.ValidId(r1, kind), gc.Equals, namestest.ValidId(r2, kind))
+	}
+}
+
+func (s *generateSuite) TestUnknownKind(c *gc.C) {
+	r := rand.New(rand.NewSource(0))
+	c.Check(func() { namestest.ValidId(r, "foo") }, gc.PanicMatches, `no generator for tag kind "foo"`)
+}
+
+func (s *generateSuite) TestQuick(c *gc.C) {
+	config := &quick.Config{Rand: rand.New(rand.NewSource(0))}
+	err := quick.Check(func(tag namestest.Tag) bool {
+		parsed, err := names.ParseTag(tag.String())
+		return err == nil && parsed == tag.Tag
+	}, config)
+	c.Check(err, jc.ErrorIsNil)
+
+	err = quick.Check(func(s namestest.TagString) bool {
+		// Anything must parse without panicking, and anything
+		// that parses must survive a round trip. The string form
+		// may differ from the input, as some kinds accept "/"
+		// in place of "-", as in "filesystem-0/1".
+		tag, err := names.ParseTag(string(s))
+		if err != nil {
+			return true
+		}
+		again, err := names.ParseTag(tag.String())
+		return err == nil && again == tag
+	}, config)
+	c.Check(err, jc.ErrorIsNil)
+
+	err = quick.Check(func(m namestest.MachineId, u namestest.UnitName, svc namestest.ServiceName,
+		rel namestest.RelationKey, user namestest.UserId, uuid namestest.UUID) bool {
+		return names.IsValidMachine(string(m)) &&
+			names.IsValidUnit(string(u)) &&
+			names.IsValidService(string(svc)) &&
+			names.IsValidRelation(string(rel)) &&
+			names.IsValidUser(string(user)) &&
+			names.IsValidUUID(string(uuid))
+	}, config)
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"errors"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type parseSuite struct{}
+
+var _ = gc.Suite(&parseSuite{})
+
+// typedParsers holds the Parse<Kind>Tag function for every kind.
+var typedParsers = map[string]func(string) (names.Tag, error){
+	names.ActionTagKind:               func(s string) (names.Tag, error) { return names.ParseActionTag(s) },
+	names.CharmTagKind:                func(s string) (names.Tag, error) { return names.ParseCharmTag(s) },
+	names.ControllerTagKind:           func(s string) (names.Tag, error) { return names.ParseControllerTag(s) },
+	names.EnvironTagKind:              func(s string) (names.Tag, error) { return names.ParseEnvironTag(s) },
+	names.FilesystemTagKind:           func(s string) (names.Tag, error) { return names.ParseFilesystemTag(s) },
+	names.FilesystemAttachmentTagKind: func(s string) (names.Tag, error) { return names.ParseFilesystemAttachmentTag(s) },
+	names.IPAddressTagKind:            func(s string) (names.Tag, error) { return names.ParseIPAddressTag(s) },
+	names.MachineTagKind:              func(s string) (names.Tag, error) { return names.ParseMachineTag(s) },
+	names.ModelTagKind:                func(s string) (names.Tag, error) { return names.ParseModelTag(s) },
+	names.PayloadTagKind:              func(s string) (names.Tag, error) { return names.ParsePayloadTag(s) },
+	names.RelationTagKind:             func(s string) (names.Tag, error) { return names.ParseRelationTag(s) },
+	names.ServiceTagKind:              func(s string) (names.Tag, error) { return names.ParseServiceTag(s) },
+	names.SpaceTagKind:                func(s string) (names.Tag, error) { return names.ParseSpaceTag(s) },
+	names.StorageTagKind:              func(s string) (names.Tag, error) { return names.ParseStorageTag(s) },
+	names.SubnetTagKind:               func(s string) (names.Tag, error) { return names.ParseSubnetTag(s) },
+	names.UnitTagKind:                 func(s string) (names.Tag, error) { return names.ParseUnitTag(s) },
+	names.UserTagKind:                 func(s string) (names.Tag, error) { return names.ParseUserTag(s) },
+	names.VolumeTagKind:               func(s string) (names.Tag, error) { return names.ParseVolumeTag(s) },
+	names.VolumeAttachmentTagKind:     func(s string) (names.Tag, error) { return names.ParseVolumeAttachmentTag(s) },
+}
+
+func (s *parseSuite) TestEveryKindHasTypedParser(c *gc.C) {
+	var kinds []string
+	for kind := range typedParsers {
+		kinds = append(kinds, kind)
+	}
+	c.Assert(kinds, jc.SameContents, namestest.Kinds())
+}
+
+// TestTypedParsersAgree checks that every typed parser accepts the
+// same tags of its kind as ParseTag, and that they all fail in the
+// same way: with ParseTag's error for strings that are not valid
+// tags, and with an error naming their own kind for valid tags of
+// other kinds.
+func (s *parseSuite) TestTypedParsersAgree(c *gc.C) {
+	for i, entry := range namestest.Corpus() {
+		c.Logf("test %d: %q", i, entry.Tag)
+		expectTag, expectErr := names.ParseTag(entry.Tag)
+		for kind, parse := range typedParsers {
+			tag, err := parse(entry.Tag)
+			switch {
+			case expectErr != nil:
+				c.Check(err, jc.DeepEquals, expectErr)
+			case kind != expectTag.Kind():
+				c.Check(err, jc.DeepEquals, names.InvalidTagError(entry.Tag, kind))
+			default:
+				c.Check(err, jc.ErrorIsNil)
+				c.Check(tag, gc.Equals, expectTag)
+				continue
+			}
+			c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+		}
+	}
+}
+
+// idExtractors holds the <Kind>IdFromTagString functions.
+var idExtractors = map[string]func(string) (string, error){
+	names.MachineTagKind: names.MachineIdFromTagString,
+	names.ModelTagKind:   names.ModelIdFromTagString,
+	names.ServiceTagKind: names.ServiceIdFromTagString,
+	names.UnitTagKind:    names.UnitIdFromTagString,
+	names.UserTagKind:    names.UserIdFromTagString,
+}
+
+// TestIdExtractorsAgree checks that every id extractor returns
+// the id of exactly the tags accepted by its typed parser.
+func (s *parseSuite) TestIdExtractorsAgree(c *gc.C) {
+	for i, entry := range namestest.Corpus() {
+		c.Logf("test %d: %q", i, entry.Tag)
+		for kind, extract := range idExtractors {
+			id, err := extract(entry.Tag)
+			tag, expectErr := typedParsers[kind](entry.Tag)
+			if expectErr != nil {
+				c.Check(err, gc.NotNil)
+				c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+				continue
+			}
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(id, gc.Equals, tag.Id())
+		}
+	}
+}
+
+func (s *parseSuite) TestIdExtractorErrors(c *gc.C) {
+	_, err := names.UnitIdFromTagString("machine-0")
+	c.Check(err, jc.DeepEquals, names.InvalidTagError("machine-0", names.UnitTagKind))
+	_, err = names.UnitIdFromTagString("unit-mysql")
+	c.Check(err, jc.DeepEquals, names.InvalidTagError("unit-mysql", names.UnitTagKind))
+}
+
+func (s *parseSuite) TestIdExtractorAllocs(c *gc.C) {
+	for i, test := range []struct {
+		kind   string
+		tag    string
+		allocs float64
+	}{
+		// Ids containing "/" are built from the tag string.
+		{names.MachineTagKind, "machine-0", 0},
+		{names.MachineTagKind, "machine-0-lxd-1", 1},
+		{names.ModelTagKind, "model-f47ac10b-58cc-4372-a567-0e02b2c3d479", 0},
+		{names.ServiceTagKind, "service-mysql", 0},
+		{names.UnitTagKind, "unit-mysql-0", 1},
+	} {
+		c.Logf("test %d: %q", i, test.tag)
+		extract := idExtractors[test.kind]
+		allocs := testing.AllocsPerRun(100, func() {
+			extract(test.tag)
+		})
+		c.Check(allocs, gc.Equals, test.allocs)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Parser parses tag strings according to configurable policies.
+// The zero Parser parses tags exactly as ParseTag does.
+type Parser struct {
+	// AliasPolicy determines how tags of deprecated kinds
+	// (see KindAliases) are treated.
+	AliasPolicy AliasPolicy
+
+	// DiscontinuedPolicy determines how tags of discontinued
+	// kinds (see DiscontinuedKinds) are treated.
+	DiscontinuedPolicy DiscontinuedPolicy
+
+	// Intern causes parsed tags to be interned (see Intern).
+	Intern bool
+
+	// MachineIdRules, if not nil, holds the rules that the ids of
+	// machine tags must satisfy, in place of those returned by
+	// DefaultMachineIdRules. It can be used, for example, to limit
+	// the nesting of containers.
+	MachineIdRules *MachineIdRules
+
+	// Unescape causes percent-encoded tag strings, as found in URL
+	// paths, to be accepted: "unit-mysql%2F0" parses as the tag of
+	// unit mysql/0. Tags that fail to parse are unescaped repeatedly
+	// until no escapes remain, so that strings escaped any number
+	// of times are decoded.
+	Unescape bool
+
+	// Warn, if not nil, is called with a description of each
+	// tag of a deprecated kind parsed under WarnAliases, and of
+	// each tag of a discontinued kind parsed under WarnDiscontinued.
+	Warn func(msg string)
+}
+
+// ParseTag parses a string representation into a Tag.
+func (p *Parser) ParseTag(s string) (Tag, error) {
+	tag, err := p.parseTag(s)
+	if err != nil {
+		return nil, err
+	}
+	if p.Intern {
+		tag = Intern(tag)
+	}
+	return tag, nil
+}
+
+func (p *Parser) parseTag(s string) (Tag, error) {
+	if p.MachineIdRules != nil && HasKindPrefix(s, MachineTagKind) {
+		return p.parseMachineTag(s)
+	}
+	tag, err := ParseTag(s)
+	if err != nil && p.Unescape {
+		if t, ok := parseEscapedTag(s); ok {
+			tag, err = t, nil
+		}
+	}
+	if err != nil {
+		if tag, ok := p.parseDiscontinuedTag(s); ok {
+			return tag, nil
+		}
+		return nil, err
+	}
+	kind, ok := kindAliases[tag.Kind()]
+	if !ok {
+		return tag, nil
+	}
+	switch p.AliasPolicy {
+	case MapAliases:
+		return Canonicalize(tag), nil
+	case WarnAliases:
+		if p.Warn != nil {
+			p.Warn(fmt.Sprintf("tag %q has deprecated kind %q; use %q instead", s, tag.Kind(), kind))
+		}
+	case RejectAliases:
+		return nil, fmt.Errorf("%q is not a valid tag: kind %q is deprecated, use %q", s, tag.Kind(), kind)
+	}
+	return tag, nil
+}
+
+// parseMachineTag parses the machine tag s under the
+// Parser's machine id rules.
+func (p *Parser) parseMachineTag(s string) (Tag, error) {
+	id := machineTagSuffixToId(s[len(MachineTagPrefix):])
+	if err := p.MachineIdRules.Validate(id); err != nil {
+		return nil, fmt.Errorf("%w: %v", invalidTagError(s, MachineTagKind), err)
+	}
+	return NewMachineTag(id), nil
+}
+
+// parseDiscontinuedTag returns the tag of a discontinued kind
+// represented by s, and whether it is accepted by the Parser.
+func (p *Parser) parseDiscontinuedTag(s string) (Tag, bool) {
+	if p.DiscontinuedPolicy == RejectDiscontinued {
+		return nil, false
+	}
+	i := strings.Index(s, "-")
+	if i <= 0 || i == len(s)-1 || !discontinuedKinds[s[:i]] {
+		return nil, false
+	}
+	tag := DiscontinuedTag{kind: s[:i], id: s[i+1:]}
+	if p.DiscontinuedPolicy == WarnDiscontinued && p.Warn != nil {
+		p.Warn(fmt.Sprintf("tag %q has discontinued kind %q", s, tag.kind))
+	}
+	return tag, true
+}
+
+// parseEscapedTag returns the tag represented by the
+// percent-encoded string s, and whether there is one.
+func parseEscapedTag(s string) (Tag, bool) {
+	for strings.Contains(s, "%") {
+		u, err := url.PathUnescape(s)
+		if err != nil || u == s {
+			break
+		}
+		s = u
+	}
+	tag, err := ParseTag(s)
+	return tag, err == nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"errors"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type parserSuite struct{}
+
+var _ = gc.Suite(&parserSuite{})
+
+const (
+	parserUUID       = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	parserEnvironTag = "environment-" + parserUUID
+)
+
+func (s *parserSuite) TestZeroParserMatchesParseTag(c *gc.C) {
+	var p names.Parser
+	for i, test := range parseTagTests {
+		c.Logf("test %d: %q", i, test.tag)
+		expectTag, expectErr := names.ParseTag(test.tag)
+		tag, err := p.ParseTag(test.tag)
+		c.Check(tag, gc.Equals, expectTag)
+		c.Check(err, gc.DeepEquals, expectErr)
+	}
+}
+
+func (s *parserSuite) TestAliasPolicies(c *gc.C) {
+	for i, test := range []struct {
+		policy names.AliasPolicy
+		expect names.Tag
+		warned bool
+		err    string
+	}{{
+		policy: names.AcceptAliases,
+		expect: names.NewEnvironTag(parserUUID),
+	}, {
+		policy: names.MapAliases,
+		expect: names.NewModelTag(parserUUID),
+	}, {
+		policy: names.WarnAliases,
+		expect: names.NewEnvironTag(parserUUID),
+		warned: true,
+	}, {
+		policy: names.RejectAliases,
+		err:    `".*" is not a valid tag: kind "environment" is deprecated, use "model"`,
+	}} {
+		c.Logf("test %d: policy %d", i, test.policy)
+		var warnings []string
+		p := names.Parser{
+			AliasPolicy: test.policy,
+			Warn:        func(msg string) { warnings = append(warnings, msg) },
+		}
+		tag, err := p.ParseTag(parserEnvironTag)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tag, gc.IsNil)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(tag, gc.Equals, test.expect)
+		}
+		if test.warned {
+			c.Check(warnings, jc.DeepEquals, []string{
+				`tag "` + parserEnvironTag + `" has deprecated kind "environment"; use "model" instead`,
+			})
+		} else {
+			c.Check(warnings, gc.HasLen, 0)
+		}
+
+		// Tags of current kinds are unaffected by the policy.
+		tag, err = p.ParseTag("model-" + parserUUID)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, names.NewModelTag(parserUUID))
+	}
+}
+
+func (s *parserSuite) TestDiscontinuedPolicies(c *gc.C) {
+	for i, test := range []struct {
+		policy names.DiscontinuedPolicy
+		warned bool
+		err    string
+	}{{
+		policy: names.RejectDiscontinued,
+		err:    `"network-foo" is not a valid tag`,
+	}, {
+		policy: names.WarnDiscontinued,
+		warned: true,
+	}, {
+		policy: names.AcceptDiscontinued,
+	}} {
+		c.Logf("test %d: policy %d", i, test.policy)
+		var warnings []string
+		p := names.Parser{
+			DiscontinuedPolicy: test.policy,
+			Warn:               func(msg string) { warnings = append(warnings, msg) },
+		}
+		tag, err := p.ParseTag("network-foo")
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tag, gc.IsNil)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(tag, gc.FitsTypeOf, names.DiscontinuedTag{})
+			c.Check(tag.Kind(), gc.Equals, "network")
+			c.Check(tag.Id(), gc.Equals, "foo")
+			c.Check(tag.String(), gc.Equals, "network-foo")
+		}
+		if test.warned {
+			c.Check(warnings, jc.DeepEquals, []string{
+				`tag "network-foo" has discontinued kind "network"`,
+			})
+		} else {
+			c.Check(warnings, gc.HasLen, 0)
+		}
+
+		// Invalid tags of other kinds are still rejected.
+		_, err = p.ParseTag("network-")
+		c.Check(err, gc.ErrorMatches, `"network-" is not a valid tag`)
+		_, err = p.ParseTag("unit-foo")
+		c.Check(err, gc.ErrorMatches, `"unit-foo" is not a valid unit tag`)
+	}
+}
+
+func (s *parserSuite) TestUnescape(c *gc.C) {
+	for i, test := range []struct {
+		tag    string
+		expect names.Tag
+	}{{
+		tag:    "unit-mysql-0",
+		expect: names.NewUnitTag("mysql/0"),
+	}, {
+		tag:    "unit-mysql%2F0",
+		expect: names.NewUnitTag("mysql/0"),
+	}, {
+		tag:    "unit-mysql%2f0",
+		expect: names.NewUnitTag("mysql/0"),
+	}, {
+		tag:    "unit-mysql%252F0",
+		expect: names.NewUnitTag("mysql/0"),
+	}, {
+		tag:    "unit-mysql/0",
+		expect: names.NewUnitTag("mysql/0"),
+	}, {
+		tag:    "machine-0%2Flxd%2F1",
+		expect: names.NewMachineTag("0/lxd/1"),
+	}, {
+		tag:    "storage-data%2F0",
+		expect: names.NewStorageTag("data/0"),
+	}, {
+		tag:    "charm-cs:trusty%2Fmysql-1",
+		expect: names.NewCharmTag("cs:trusty/mysql-1"),
+	}, {
+		tag:    "user-bob%40example.com",
+		expect: names.NewUserTag("bob@example.com"),
+	}} {
+		c.Logf("test %d: %q", i, test.tag)
+		p := names.Parser{Unescape: true}
+		tag, err := p.ParseTag(test.tag)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, test.expect)
+
+		if strings.Contains(test.tag, "%") {
+			_, err := new(names.Parser).ParseTag(test.tag)
+			c.Check(err, gc.NotNil)
+		}
+	}
+}
+
+func (s *parserSuite) TestUnescapeErrors(c *gc.C) {
+	p := names.Parser{Unescape: true}
+	for i, tag := range []string{
+		"unit-mysql%2Fx",
+		"unit-mysql%zz0",
+		"unit-mysql%2F0%2F1",
+		"foo-bar%2F0",
+		"%2F",
+	} {
+		c.Logf("test %d: %q", i, tag)
+		_, err := p.ParseTag(tag)
+		c.Check(err, gc.ErrorMatches, `".*" is not a valid( unit)? tag`)
+	}
+}
+
+func (s *parserSuite) TestMachineIdRules(c *gc.C) {
+	rules := names.DefaultMachineIdRules()
+	rules.MaxNestingLevel = 1
+	rules.ContainerTypes = append(rules.ContainerTypes, "docker")
+	p := names.Parser{MachineIdRules: &rules}
+
+	tag, err := p.ParseTag("machine-0-lxd-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tag, gc.Equals, names.NewMachineTag("0/lxd/1"))
+
+	tag, err = p.ParseTag("machine-0-docker-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tag, gc.Equals, names.NewMachineTag("0/docker/1"))
+
+	_, err = p.ParseTag("machine-0-lxd-1-kvm-2")
+	c.Check(err, gc.ErrorMatches, `"machine-0-lxd-1-kvm-2" is not a valid machine tag: `+
+		`"0/lxd/1/kvm/2" is not a valid machine id: nesting level 2 exceeds maximum of 1`)
+	c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+
+	_, err = p.ParseTag("machine-")
+	c.Check(err, gc.ErrorMatches, `"machine-" is not a valid machine tag: .*`)
+
+	// Other kinds are unaffected, and the default
+	// rules still apply without the parser.
+	tag, err = p.ParseTag("unit-mysql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tag, gc.Equals, names.NewUnitTag("mysql/0"))
+	_, err = names.ParseTag("machine-0-docker-1")
+	c.Check(err, gc.ErrorMatches, `"machine-0-docker-1" is not a valid machine tag`)
+	c.Check(names.IsValidMachine("0/lxd/1/kvm/2"), jc.IsTrue)
+}
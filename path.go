@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// TagsFromPath returns the tags named by a URL path made up of
+// alternating kind and id segments, such as
+// "/model/<uuid>/machine/0". Ids containing "/" (e.g. unit names)
+// must be escaped within their segment ("/unit/mysql%2F0").
+func TagsFromPath(path string) ([]Tag, error) {
+	segments, err := pathSegments(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments)%2 != 0 {
+		return nil, fmt.Errorf("path %q does not consist of kind/id pairs", path)
+	}
+	tags := make([]Tag, 0, len(segments)/2)
+	for i := 0; i < len(segments); i += 2 {
+		tag, err := pathTag(path, segments[i], segments[i+1])
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// PathPattern matches URL paths against a pattern such as
+// "/model/{model}/machine/{machine}", in which each segment is
+// either a literal or a placeholder naming the kind of tag whose
+// id appears in that position.
+type PathPattern struct {
+	pattern  string
+	segments []string
+}
+
+// NewPathPattern returns a PathPattern for the given pattern. It
+// returns an error if any placeholder does not name a valid tag kind.
+func NewPathPattern(pattern string) (*PathPattern, error) {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	for _, segment := range segments {
+		if kind, ok := placeholderKind(segment); ok && !validKinds(kind) {
+			return nil, fmt.Errorf("path pattern %q: %q is not a valid tag kind", pattern, kind)
+		}
+	}
+	return &PathPattern{
+		pattern:  pattern,
+		segments: segments,
+	}, nil
+}
+
+// String returns the pattern p was created with.
+func (p *PathPattern) String() string {
+	return p.pattern
+}
+
+// Match matches path against the pattern, returning the tags
+// corresponding to its placeholders, in order.
+func (p *PathPattern) Match(path string) ([]Tag, error) {
+	segments, err := pathSegments(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) != len(p.segments) {
+		return nil, fmt.Errorf("path %q does not match %q", path, p.pattern)
+	}
+	var tags []Tag
+	for i, segment := range p.segments {
+		kind, ok := placeholderKind(segment)
+		if !ok {
+			if segments[i] != segment {
+				return nil, fmt.Errorf("path %q does not match %q", path, p.pattern)
+			}
+			continue
+		}
+		tag, err := pathTag(path, kind, segments[i])
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func placeholderKind(segment string) (string, bool) {
+	if len(segment) < 2 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+		return "", false
+	}
+	return segment[1 : len(segment)-1], true
+}
+
+// pathSegments returns the unescaped segments of path.
+func pathSegments(path string) ([]string, error) {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil, nil
+	}
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		s, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %v", path, err)
+		}
+		segments[i] = s
+	}
+	return segments, nil
+}
+
+func pathTag(path, kind, id string) (Tag, error) {
+	if !validKinds(kind) {
+		return nil, fmt.Errorf("path %q: %q is not a valid tag kind", path, kind)
+	}
+	tag, ok := tagFromId(kind, id)
+	if !ok {
+		return nil, fmt.Errorf("path %q: %q is not a valid %s id", path, id, kind)
+	}
+	return tag, nil
+}
+
+// pathSegment returns the string form of tag escaped so that
+// it is safe for use as a single file name or URL path segment.
+// Most tag strings need no escaping; those that do, such as
+// charm tags, have their "/" characters percent-encoded.
+func pathSegment(tag Tag) string {
+	return url.PathEscape(tag.String())
+}
+
+// TagFromPathSegment returns the tag whose PathSegment
+// method returns segment.
+func TagFromPathSegment(segment string) (Tag, error) {
+	s, err := url.PathUnescape(segment)
+	if err != nil || strings.Contains(segment, "/") {
+		return nil, fmt.Errorf("%q is not a valid tag path segment", segment)
+	}
+	return ParseTag(s)
+}
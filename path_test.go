@@ -0,0 +1,187 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type pathSuite struct{}
+
+var _ = gc.Suite(&pathSuite{})
+
+const pathModelUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func (s *pathSuite) TestTagsFromPath(c *gc.C) {
+	for i, test := range []struct {
+		path   string
+		expect []names.Tag
+		err    string
+	}{{
+		path:   "/",
+		expect: []names.Tag{},
+	}, {
+		path: "/model/" + pathModelUUID + "/machine/0",
+		expect: []names.Tag{
+			names.NewModelTag(pathModelUUID),
+			names.NewMachineTag("0"),
+		},
+	}, {
+		path: "/unit/mysql%2F0/storage/data%2F1/",
+		expect: []names.Tag{
+			names.NewUnitTag("mysql/0"),
+			names.NewStorageTag("data/1"),
+		},
+	}, {
+		path: "/model/" + pathModelUUID + "/machine",
+		err:  `path ".*" does not consist of kind/id pairs`,
+	}, {
+		path: "/foo/bar",
+		err:  `path "/foo/bar": "foo" is not a valid tag kind`,
+	}, {
+		path: "/machine/bar",
+		err:  `path "/machine/bar": "bar" is not a valid machine id`,
+	}, {
+		path: "/unit/mysql%zz",
+		err:  `path "/unit/mysql%zz": .*`,
+	}, {
+		path:   "/user/bob+1@local",
+		expect: []names.Tag{names.NewUserTag("bob+1@local")},
+	}} {
+		c.Logf("test %d: %q", i, test.path)
+		tags, err := names.TagsFromPath(test.path)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tags, gc.IsNil)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tags, jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *pathSuite) TestNewPathPatternInvalidKind(c *gc.C) {
+	_, err := names.NewPathPattern("/model/{model}/foo/{foo}")
+	c.Assert(err, gc.ErrorMatches, `path pattern "/model/{model}/foo/{foo}": "foo" is not a valid tag kind`)
+}
+
+func (s *pathSuite) TestPathPatternMatch(c *gc.C) {
+	p, err := names.NewPathPattern("/model/{model}/machine/{machine}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.String(), gc.Equals, "/model/{model}/machine/{machine}")
+
+	for i, test := range []struct {
+		path   string
+		expect []names.Tag
+		err    string
+	}{{
+		path: "/model/" + pathModelUUID + "/machine/0%2Flxc%2F1",
+		expect: []names.Tag{
+			names.NewModelTag(pathModelUUID),
+			names.NewMachineTag("0/lxc/1"),
+		},
+	}, {
+		path: "/model/" + pathModelUUID + "/unit/0",
+		err:  `path ".*" does not match "/model/{model}/machine/{machine}"`,
+	}, {
+		path: "/model/" + pathModelUUID,
+		err:  `path ".*" does not match "/model/{model}/machine/{machine}"`,
+	}, {
+		path: "/model/foo/machine/0",
+		err:  `path "/model/foo/machine/0": "foo" is not a valid model id`,
+	}} {
+		c.Logf("test %d: %q", i, test.path)
+		tags, err := p.Match(test.path)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tags, gc.IsNil)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tags, jc.DeepEquals, test.expect)
+	}
+}
+
+type pathSegmenter interface {
+	names.Tag
+	PathSegment() string
+}
+
+func (s *pathSuite) TestPathSegment(c *gc.C) {
+	for i, test := range []struct {
+		tag     names.Tag
+		segment string
+	}{{
+		tag:     names.NewUnitTag("mysql/0"),
+		segment: "unit-mysql-0",
+	}, {
+		tag:     names.NewStorageTag("data/0"),
+		segment: "storage-data-0",
+	}, {
+		tag:     names.NewMachineTag("0/lxd/1"),
+		segment: "machine-0-lxd-1",
+	}, {
+		tag:     names.NewCharmTag("cs:trusty/mysql-1"),
+		segment: "charm-cs:trusty%2Fmysql-1",
+	}, {
+		tag:     names.NewSubnetTag("10.0.0.0/24"),
+		segment: "subnet-10.0.0.0%2F24",
+	}, {
+		tag:     names.NewRelationTag("wordpress:db mysql:server"),
+		segment: "relation-wordpress.db%23mysql.server",
+	}, {
+		tag:     names.NewUserTag("bob@example.com"),
+		segment: "user-bob@example.com",
+	}} {
+		c.Logf("test %d: %s", i, test.tag)
+		segment := test.tag.(pathSegmenter).PathSegment()
+		c.Check(segment, gc.Equals, test.segment)
+		tag, err := names.TagFromPathSegment(segment)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, test.tag)
+	}
+}
+
+func (s *pathSuite) TestPathSegmentRoundTrip(c *gc.C) {
+	for _, entry := range namestest.Corpus() {
+		if !entry.Valid {
+			continue
+		}
+		tag, err := names.ParseTag(entry.Tag)
+		c.Assert(err, jc.ErrorIsNil)
+		ps, ok := tag.(pathSegmenter)
+		c.Assert(ok, jc.IsTrue, gc.Commentf("%s has no PathSegment method", entry.Tag))
+		segment := ps.PathSegment()
+		c.Check(strings.Contains(segment, "/"), jc.IsFalse, gc.Commentf("%q", segment))
+		got, err := names.TagFromPathSegment(segment)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(names.Equal(got, tag), jc.IsTrue, gc.Commentf("%q", segment))
+	}
+}
+
+func (s *pathSuite) TestTagFromPathSegmentErrors(c *gc.C) {
+	for i, test := range []struct {
+		segment string
+		err     string
+	}{{
+		segment: "charm-cs:trusty/mysql-1",
+		err:     `"charm-cs:trusty/mysql-1" is not a valid tag path segment`,
+	}, {
+		segment: "unit-mysql%zz",
+		err:     `"unit-mysql%zz" is not a valid tag path segment`,
+	}, {
+		segment: "unit-mysql",
+		err:     `"unit-mysql" is not a valid unit tag`,
+	}} {
+		c.Logf("test %d: %q", i, test.segment)
+		_, err := names.TagFromPathSegment(test.segment)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -4,9 +4,8 @@
 package names
 
 import (
-	"regexp"
-
-	"github.com/juju/utils"
+	"fmt"
+	"strings"
 )
 
 const (
@@ -16,19 +15,28 @@ const (
 
 	// This can be expanded later, as needed.
 	payloadClass = "([a-zA-Z](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)"
+
+	// payloadRawId matches the ID given to a payload by the
+	// technology running it, e.g. a docker container ID.
+	payloadRawId = "([a-zA-Z0-9](?:[a-zA-Z0-9._-]*[a-zA-Z0-9])?)"
 )
 
-var validPayload = regexp.MustCompile("^" + payloadClass + "$")
+var (
+	validPayload      = newLazyRegexp("^" + payloadClass + "(?:/" + payloadRawId + ")?$")
+	validPayloadClass = newLazyRegexp("^" + payloadClass + "$")
+	validPayloadRawId = newLazyRegexp("^" + payloadRawId + "$")
+)
 
 // IsValidPayload returns whether id is a valid Juju ID for
-// a charm payload. The ID must be a valid alpha-numeric (plus hyphens).
+// a charm payload. The ID must be a valid alpha-numeric (plus hyphens),
+// optionally qualified by the payload's raw ID, as in "<class>/<raw-id>".
 func IsValidPayload(id string) bool {
 	return validPayload.MatchString(id)
 }
 
 // For compatibility with Juju 1.25, UUIDs are also supported.
 func isValidPayload(id string) bool {
-	return IsValidPayload(id) || utils.IsValidUUIDString(id)
+	return IsValidPayload(id) || IsValidUUID(id)
 }
 
 // PayloadTag represents a charm payload.
@@ -43,6 +51,18 @@ func NewPayloadTag(id string) PayloadTag {
 	}
 }
 
+// NewPayloadTagFromParts returns the tag for the payload of
+// the given class with the given raw ID.
+func NewPayloadTagFromParts(class, rawId string) (PayloadTag, error) {
+	if !validPayloadClass.MatchString(class) {
+		return PayloadTag{}, fmt.Errorf("%q is not a valid payload class", class)
+	}
+	if !validPayloadRawId.MatchString(rawId) {
+		return PayloadTag{}, fmt.Errorf("%q is not a valid payload raw ID", rawId)
+	}
+	return NewPayloadTag(class + "/" + rawId), nil
+}
+
 // ParsePayloadTag parses a payload tag string.
 // So ParsePayloadTag(tag.String()) === tag.
 func ParsePayloadTag(tag string) (PayloadTag, error) {
@@ -72,3 +92,32 @@ func (t PayloadTag) Id() string {
 func (t PayloadTag) String() string {
 	return tagString(t)
 }
+
+// Validate implements SelfValidator.
+func (t PayloadTag) Validate() error {
+	return validateTag(t)
+}
+
+// PathSegment returns the tag in a form safe for use
+// as a single path segment (see TagFromPathSegment).
+func (t PayloadTag) PathSegment() string {
+	return pathSegment(t)
+}
+
+// Class returns the payload's class, as defined in the charm's
+// metadata, or the empty string if the ID of the payload is not
+// of the form "<class>/<raw-id>".
+func (t PayloadTag) Class() string {
+	i := strings.Index(t.id, "/")
+	if i == -1 {
+		return ""
+	}
+	return t.id[:i]
+}
+
+// RawId returns the ID given to the payload by the technology
+// running it, or the whole ID of the payload if it is not of the
+// form "<class>/<raw-id>".
+func (t PayloadTag) RawId() string {
+	return t.id[strings.Index(t.id, "/")+1:]
+}
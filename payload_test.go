@@ -42,6 +42,12 @@ func (s *payloadSuite) TestIsValidPayload(c *gc.C) {
 		{"spam-", false},
 		{"spam", true},
 		{"spam-and-eggs", true},
+		{"docker/0a1b2c3d", true},
+		{"spam-and-eggs/my_id.2", true},
+		{"spam/", false},
+		{"/0a1b2c3d", false},
+		{"spam/id-", false},
+		{"spam/a/b", false},
 
 		{"f47ac10b-58cc-4372-a567-0e02b2c3d479", true},
 	} {
@@ -69,6 +75,9 @@ func (s *payloadSuite) TestParsePayloadTag(c *gc.C) {
 	}, {
 		tag:      "payload-f47ac10b-58cc-4372-a567-0e02b2c3d479",
 		expected: names.NewPayloadTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	}, {
+		tag:      "payload-docker/0a1b2c3d",
+		expected: names.NewPayloadTag("docker/0a1b2c3d"),
 	}, {
 		tag: "spam",
 		err: names.InvalidTagError("spam", ""),
@@ -92,3 +101,32 @@ func (s *payloadSuite) TestParsePayloadTag(c *gc.C) {
 		}
 	}
 }
+
+func (s *payloadSuite) TestPayloadTagParts(c *gc.C) {
+	for i, test := range []struct {
+		id    string
+		class string
+		rawId string
+	}{
+		{"docker/0a1b2c3d", "docker", "0a1b2c3d"},
+		{"spam-and-eggs/my_id.2", "spam-and-eggs", "my_id.2"},
+		{"spam", "", "spam"},
+		{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "", "f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+	} {
+		c.Logf("test %d: %s", i, test.id)
+		tag := names.NewPayloadTag(test.id)
+		c.Check(tag.Class(), gc.Equals, test.class)
+		c.Check(tag.RawId(), gc.Equals, test.rawId)
+	}
+}
+
+func (s *payloadSuite) TestNewPayloadTagFromParts(c *gc.C) {
+	tag, err := names.NewPayloadTagFromParts("docker", "0a1b2c3d")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tag, gc.Equals, names.NewPayloadTag("docker/0a1b2c3d"))
+
+	_, err = names.NewPayloadTagFromParts("spam-", "0a1b2c3d")
+	c.Check(err, gc.ErrorMatches, `"spam-" is not a valid payload class`)
+	_, err = names.NewPayloadTagFromParts("docker", "a/b")
+	c.Check(err, gc.ErrorMatches, `"a/b" is not a valid payload raw ID`)
+}
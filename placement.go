@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MachineScope is the scope of placement directives
+	// naming an existing machine.
+	MachineScope = "#"
+
+	// ModelScope is the scope of placement directives that are
+	// interpreted by the model's provider, such as "zone=us-east-1a".
+	ModelScope = ""
+)
+
+var validPlacementScope = newLazyRegexp("^[a-z][a-z0-9-]*$")
+
+// Placement is a parsed placement directive, as given to
+// commands such as add-machine and deploy --to.
+type Placement struct {
+	// Scope holds the scope of the directive: MachineScope,
+	// ModelScope, a container type (see ContainerSpec), or a
+	// provider-specific scope such as "ssh".
+	Scope string
+
+	// Directive holds the directive itself, whose meaning
+	// depends on the scope.
+	Directive string
+}
+
+// ParsePlacement parses a placement directive. The following
+// forms are recognised:
+//
+//	<machine-id>                  an existing machine, e.g. "4"
+//	<container-type>[:<machine>]  a new container, e.g. "lxd:4" (see ParseContainerSpec)
+//	<scope>:<directive>           a scoped directive, e.g. "ssh:ubuntu@host"
+//	<directive>                   a provider directive, e.g. "zone=us-east-1a"
+func ParsePlacement(directive string) (Placement, error) {
+	if directive == "" {
+		return Placement{}, fmt.Errorf("placement directive is empty")
+	}
+	if IsValidMachine(directive) {
+		return Placement{Scope: MachineScope, Directive: directive}, nil
+	}
+	scope, value := directive, ""
+	i := strings.Index(directive, ":")
+	if i != -1 && !strings.Contains(directive[:i], "=") {
+		scope, value = directive[:i], directive[i+1:]
+	} else if defaultMachineIdRules.validateContainerType(directive) != nil {
+		return Placement{Scope: ModelScope, Directive: directive}, nil
+	}
+	if !validPlacementScope.MatchString(scope) {
+		return Placement{}, fmt.Errorf("invalid placement directive %q: invalid scope %q", directive, scope)
+	}
+	if defaultMachineIdRules.validateContainerType(scope) == nil {
+		if _, err := ParseContainerSpec(directive); err != nil {
+			return Placement{}, fmt.Errorf("invalid placement directive %q: %v", directive, err)
+		}
+	} else if value == "" {
+		return Placement{}, fmt.Errorf("invalid placement directive %q: empty directive for scope %q", directive, scope)
+	}
+	return Placement{Scope: scope, Directive: value}, nil
+}
+
+// Machine returns the tag of the existing machine named by the
+// placement, and whether the placement names one.
+func (p Placement) Machine() (MachineTag, bool) {
+	if p.Scope != MachineScope {
+		return MachineTag{}, false
+	}
+	return NewMachineTag(p.Directive), true
+}
+
+// ContainerSpec returns the container specified by the placement,
+// and whether the placement specifies one.
+func (p Placement) ContainerSpec() (ContainerSpec, bool) {
+	if p.Scope == MachineScope || p.Scope == ModelScope {
+		return ContainerSpec{}, false
+	}
+	spec, err := ParseContainerSpec(p.String())
+	if err != nil {
+		return ContainerSpec{}, false
+	}
+	return spec, true
+}
+
+// String returns the placement in the form accepted by ParsePlacement.
+func (p Placement) String() string {
+	switch {
+	case p.Scope == MachineScope || p.Scope == ModelScope:
+		return p.Directive
+	case p.Directive == "":
+		return p.Scope
+	}
+	return p.Scope + ":" + p.Directive
+}
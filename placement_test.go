@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type placementSuite struct{}
+
+var _ = gc.Suite(&placementSuite{})
+
+func (s *placementSuite) TestParsePlacement(c *gc.C) {
+	for i, test := range []struct {
+		directive string
+		expect    names.Placement
+		err       string
+	}{{
+		directive: "4",
+		expect:    names.Placement{Scope: names.MachineScope, Directive: "4"},
+	}, {
+		directive: "0/lxd/1",
+		expect:    names.Placement{Scope: names.MachineScope, Directive: "0/lxd/1"},
+	}, {
+		directive: "lxd",
+		expect:    names.Placement{Scope: "lxd"},
+	}, {
+		directive: "lxd:4",
+		expect:    names.Placement{Scope: "lxd", Directive: "4"},
+	}, {
+		directive: "kvm:new",
+		expect:    names.Placement{Scope: "kvm", Directive: "new"},
+	}, {
+		directive: "zone=us-east-1a",
+		expect:    names.Placement{Scope: names.ModelScope, Directive: "zone=us-east-1a"},
+	}, {
+		directive: "tags=a:b",
+		expect:    names.Placement{Scope: names.ModelScope, Directive: "tags=a:b"},
+	}, {
+		directive: "node1.maas",
+		expect:    names.Placement{Scope: names.ModelScope, Directive: "node1.maas"},
+	}, {
+		directive: "ssh:ubuntu@10.0.0.1",
+		expect:    names.Placement{Scope: "ssh", Directive: "ubuntu@10.0.0.1"},
+	}, {
+		directive: "",
+		err:       "placement directive is empty",
+	}, {
+		directive: "lxd:foo",
+		err:       `invalid placement directive "lxd:foo": invalid container spec "lxd:foo": invalid machine id "foo"`,
+	}, {
+		directive: "ssh:",
+		err:       `invalid placement directive "ssh:": empty directive for scope "ssh"`,
+	}, {
+		directive: "SSH:host",
+		err:       `invalid placement directive "SSH:host": invalid scope "SSH"`,
+	}} {
+		c.Logf("test %d: %q", i, test.directive)
+		p, err := names.ParsePlacement(test.directive)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(p, jc.DeepEquals, test.expect)
+		if test.directive != "kvm:new" {
+			c.Check(p.String(), gc.Equals, test.directive)
+		}
+	}
+}
+
+func (s *placementSuite) TestPlacementMachine(c *gc.C) {
+	p, err := names.ParsePlacement("0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	machine, ok := p.Machine()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(machine, gc.Equals, names.NewMachineTag("0/lxd/1"))
+	_, ok = p.ContainerSpec()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *placementSuite) TestPlacementContainerSpec(c *gc.C) {
+	p, err := names.ParsePlacement("lxd:4")
+	c.Assert(err, jc.ErrorIsNil)
+	spec, ok := p.ContainerSpec()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(spec, jc.DeepEquals, names.ContainerSpec{
+		ContainerType: "lxd",
+		Machine:       names.NewMachineTag("4"),
+	})
+	_, ok = p.Machine()
+	c.Assert(ok, jc.IsFalse)
+
+	p, err = names.ParsePlacement("ssh:host")
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = p.ContainerSpec()
+	c.Assert(ok, jc.IsFalse)
+}
@@ -0,0 +1,45 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+// The prefixes with which the string forms of tags of each kind start.
+const (
+	ActionTagPrefix               = ActionTagKind + "-"
+	CharmTagPrefix                = CharmTagKind + "-"
+	ControllerTagPrefix           = ControllerTagKind + "-"
+	EnvironTagPrefix              = EnvironTagKind + "-"
+	FilesystemTagPrefix           = FilesystemTagKind + "-"
+	FilesystemAttachmentTagPrefix = FilesystemAttachmentTagKind + "-"
+	IPAddressTagPrefix            = IPAddressTagKind + "-"
+	MachineTagPrefix              = MachineTagKind + "-"
+	ModelTagPrefix                = ModelTagKind + "-"
+	PayloadTagPrefix              = PayloadTagKind + "-"
+	RelationTagPrefix             = RelationTagKind + "-"
+	ServiceTagPrefix              = ServiceTagKind + "-"
+	SpaceTagPrefix                = SpaceTagKind + "-"
+	StorageTagPrefix              = StorageTagKind + "-"
+	SubnetTagPrefix               = SubnetTagKind + "-"
+	UnitTagPrefix                 = UnitTagKind + "-"
+	UserTagPrefix                 = UserTagKind + "-"
+	VolumeTagPrefix               = VolumeTagKind + "-"
+	VolumeAttachmentTagPrefix     = VolumeAttachmentTagKind + "-"
+)
+
+// HasKindPrefix returns whether s starts with the prefix of tags of
+// the given kind, as a cheap check before parsing it. It does not
+// check the rest of s, nor whether kind is valid.
+func HasKindPrefix(s, kind string) bool {
+	return len(s) > len(kind) && s[len(kind)] == '-' && s[:len(kind)] == kind
+}
+
+// StripKind returns s without the prefix of tags of the given kind.
+// The remainder is returned as it appears in s: it is not converted
+// to an id (unit-mysql-0 yields "mysql-0", not "mysql/0") nor
+// validated. It returns an error if s does not have the prefix.
+func StripKind(kind, s string) (string, error) {
+	if !HasKindPrefix(s, kind) {
+		return "", invalidTagError(s, kind)
+	}
+	return s[len(kind)+1:], nil
+}
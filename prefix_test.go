@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"errors"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type prefixSuite struct{}
+
+var _ = gc.Suite(&prefixSuite{})
+
+func (s *prefixSuite) TestPrefixes(c *gc.C) {
+	for kind, prefix := range map[string]string{
+		names.ActionTagKind:               names.ActionTagPrefix,
+		names.CharmTagKind:                names.CharmTagPrefix,
+		names.ControllerTagKind:           names.ControllerTagPrefix,
+		names.EnvironTagKind:              names.EnvironTagPrefix,
+		names.FilesystemTagKind:           names.FilesystemTagPrefix,
+		names.FilesystemAttachmentTagKind: names.FilesystemAttachmentTagPrefix,
+		names.IPAddressTagKind:            names.IPAddressTagPrefix,
+		names.MachineTagKind:              names.MachineTagPrefix,
+		names.ModelTagKind:                names.ModelTagPrefix,
+		names.PayloadTagKind:              names.PayloadTagPrefix,
+		names.RelationTagKind:             names.RelationTagPrefix,
+		names.ServiceTagKind:              names.ServiceTagPrefix,
+		names.SpaceTagKind:                names.SpaceTagPrefix,
+		names.StorageTagKind:              names.StorageTagPrefix,
+		names.SubnetTagKind:               names.SubnetTagPrefix,
+		names.UnitTagKind:                 names.UnitTagPrefix,
+		names.UserTagKind:                 names.UserTagPrefix,
+		names.VolumeTagKind:               names.VolumeTagPrefix,
+		names.VolumeAttachmentTagKind:     names.VolumeAttachmentTagPrefix,
+	} {
+		c.Check(prefix, gc.Equals, kind+"-")
+	}
+}
+
+func (s *prefixSuite) TestHasKindPrefix(c *gc.C) {
+	for i, test := range []struct {
+		s      string
+		kind   string
+		expect bool
+	}{
+		{"unit-mysql-0", names.UnitTagKind, true},
+		{"unit-", names.UnitTagKind, true},
+		{"unit", names.UnitTagKind, false},
+		{"units-mysql-0", names.UnitTagKind, false},
+		{"unit-mysql-0", names.UserTagKind, false},
+		{"user-bob", "", false},
+		{"-bob", "", true},
+		{"", names.UnitTagKind, false},
+	} {
+		c.Logf("test %d: %q %q", i, test.s, test.kind)
+		c.Check(names.HasKindPrefix(test.s, test.kind), gc.Equals, test.expect)
+	}
+}
+
+func (s *prefixSuite) TestHasKindPrefixAgreesWithParseTag(c *gc.C) {
+	for _, entry := range namestest.Corpus() {
+		if !entry.Valid {
+			continue
+		}
+		tag, err := names.ParseTag(entry.Tag)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(names.HasKindPrefix(entry.Tag, tag.Kind()), jc.IsTrue, gc.Commentf("%q", entry.Tag))
+	}
+}
+
+func (s *prefixSuite) TestStripKind(c *gc.C) {
+	for i, test := range []struct {
+		kind   string
+		s      string
+		expect string
+		err    string
+	}{
+		{kind: names.UnitTagKind, s: "unit-mysql-0", expect: "mysql-0"},
+		{kind: names.CharmTagKind, s: "charm-cs:trusty/mysql-1", expect: "cs:trusty/mysql-1"},
+		{kind: names.UnitTagKind, s: "unit-", expect: ""},
+		{kind: names.UnitTagKind, s: "unit", err: `"unit" is not a valid unit tag`},
+		{kind: names.UnitTagKind, s: "machine-0", err: `"machine-0" is not a valid unit tag`},
+	} {
+		c.Logf("test %d: %q %q", i, test.kind, test.s)
+		got, err := names.StripKind(test.kind, test.s)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(got, gc.Equals, test.expect)
+	}
+}
+
+func (s *prefixSuite) TestHasKindPrefixAllocs(c *gc.C) {
+	allocs := testing.AllocsPerRun(100, func() {
+		names.HasKindPrefix("unit-mysql-0", names.UnitTagKind)
+	})
+	c.Assert(allocs, gc.Equals, 0.0)
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// RedactedMask replaces the sensitive parts of redacted tags.
+const RedactedMask = "***"
+
+// redactSensitiveTags is non-zero if sensitive parts of tags
+// are to be masked. It is accessed atomically.
+var redactSensitiveTags int32
+
+// SetRedactSensitiveTags sets whether ReadableString and the logging
+// adapters mask the sensitive parts of tags, as returned by
+// RedactedString. It is intended for deployments whose log output
+// is subject to regulation. Redaction is off by default.
+func SetRedactSensitiveTags(redact bool) {
+	var v int32
+	if redact {
+		v = 1
+	}
+	atomic.StoreInt32(&redactSensitiveTags, v)
+}
+
+// RedactSensitiveTags returns whether ReadableString and the logging
+// adapters mask the sensitive parts of tags (see SetRedactSensitiveTags).
+func RedactSensitiveTags() bool {
+	return atomic.LoadInt32(&redactSensitiveTags) != 0
+}
+
+// Redacter is implemented by tags whose string form can
+// contain sensitive information, such as the domains
+// of external users.
+type Redacter interface {
+	// RedactedString returns the string form of the
+	// tag with its sensitive parts replaced by RedactedMask.
+	RedactedString() string
+}
+
+// RedactedString returns the string form of tag with its sensitive
+// parts masked. Tags that do not implement Redacter are returned
+// in full. It returns the empty string if tag is nil.
+func RedactedString(tag Tag) string {
+	switch tag := tag.(type) {
+	case nil:
+		return ""
+	case Redacter:
+		return tag.RedactedString()
+	}
+	return tag.String()
+}
+
+// displayId returns the id of tag for display, masking its
+// sensitive parts if redaction is enabled.
+func displayId(tag Tag) string {
+	if RedactSensitiveTags() {
+		if r, ok := tag.(Redacter); ok {
+			return strings.TrimPrefix(r.RedactedString(), tag.Kind()+"-")
+		}
+	}
+	return tag.Id()
+}
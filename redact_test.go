@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type redactSuite struct{}
+
+var _ = gc.Suite(&redactSuite{})
+
+var redactTests = []struct {
+	tag      names.Tag
+	redacted string
+	plain    string
+	readable string
+}{{
+	tag:      names.NewUserTag("bob"),
+	redacted: "user-bob",
+	plain:    "user bob",
+	readable: "user bob",
+}, {
+	tag:      names.NewUserTag("bob@local"),
+	redacted: "user-bob@local",
+	plain:    "user bob@local",
+	readable: "user bob@local",
+}, {
+	tag:      names.NewUserTag("bob@example.com"),
+	redacted: "user-bob@***",
+	plain:    "user bob@example.com",
+	readable: "user bob@***",
+}, {
+	tag:      names.NewUserTag(names.EveryoneUserName),
+	redacted: "user-everyone@***",
+	plain:    "user everyone@external",
+	readable: "user everyone@***",
+}, {
+	tag:      names.NewMachineTag("0/lxd/1"),
+	redacted: "machine-0-lxd-1",
+	plain:    "container 1 (lxd) on machine 0",
+	readable: "container 1 (lxd) on machine 0",
+}, {
+	tag:      names.NewUnitTag("mysql/0"),
+	redacted: "unit-mysql-0",
+	plain:    "unit 0 of mysql",
+	readable: "unit 0 of mysql",
+}}
+
+func (s *redactSuite) TestRedactedString(c *gc.C) {
+	for i, test := range redactTests {
+		c.Logf("test %d: %s", i, test.tag)
+		c.Check(names.RedactedString(test.tag), gc.Equals, test.redacted)
+	}
+	c.Check(names.RedactedString(nil), gc.Equals, "")
+}
+
+func (s *redactSuite) TestReadableStringRedaction(c *gc.C) {
+	defer names.SetRedactSensitiveTags(names.RedactSensitiveTags())
+	for i, test := range redactTests {
+		c.Logf("test %d: %s", i, test.tag)
+		names.SetRedactSensitiveTags(false)
+		c.Check(names.ReadableString(test.tag), gc.Equals, test.plain)
+		names.SetRedactSensitiveTags(true)
+		c.Check(names.ReadableString(test.tag), gc.Equals, test.readable)
+	}
+}
@@ -5,7 +5,7 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -19,8 +19,9 @@ const RelationSnippet = "[a-z][a-z0-9]*(?:[_-][a-z0-9]+)*"
 // For peer relations, the format is "relation-service.rel"
 
 var (
-	validRelation     = regexp.MustCompile("^" + ServiceSnippet + ":" + RelationSnippet + " " + ServiceSnippet + ":" + RelationSnippet + "$")
-	validPeerRelation = regexp.MustCompile("^" + ServiceSnippet + ":" + RelationSnippet + "$")
+	validRelationName = newLazyRegexp("^" + RelationSnippet + "$")
+	validRelation     = newLazyRegexp("^" + ServiceSnippet + ":" + RelationSnippet + " " + ServiceSnippet + ":" + RelationSnippet + "$")
+	validPeerRelation = newLazyRegexp("^" + ServiceSnippet + ":" + RelationSnippet + "$")
 )
 
 // IsValidRelation returns whether key is a valid relation key.
@@ -28,13 +29,79 @@ func IsValidRelation(key string) bool {
 	return validRelation.MatchString(key) || validPeerRelation.MatchString(key)
 }
 
+// RelationRole is the role an endpoint plays in a relation,
+// as declared by the endpoint's charm.
+type RelationRole string
+
+const (
+	RoleProvider RelationRole = "provider"
+	RoleRequirer RelationRole = "requirer"
+	RolePeer     RelationRole = "peer"
+)
+
+// Endpoint identifies one end of a relation: a relation
+// name as provided or required by a service's charm.
+type Endpoint struct {
+	Service  string
+	Relation string
+	Role     RelationRole
+}
+
+// String returns the endpoint in the form used in
+// relation keys, "<service>:<relation>".
+func (ep Endpoint) String() string {
+	return ep.Service + ":" + ep.Relation
+}
+
+// IsValidRelationEndpointName returns whether name is a valid
+// relation name, as declared by a charm for one of its endpoints.
+func IsValidRelationEndpointName(name string) bool {
+	return validRelationName.MatchString(name)
+}
+
 type RelationTag struct {
 	key string
 }
 
-func (t RelationTag) String() string { return t.Kind() + "-" + t.key }
-func (t RelationTag) Kind() string   { return RelationTagKind }
-func (t RelationTag) Id() string     { return relationTagSuffixToKey(t.key) }
+func (t RelationTag) String() string      { return t.Kind() + "-" + t.key }
+func (t RelationTag) Kind() string        { return RelationTagKind }
+func (t RelationTag) Id() string          { return relationTagSuffixToKey(t.key) }
+func (t RelationTag) Validate() error     { return validateTag(t) }
+func (t RelationTag) PathSegment() string { return pathSegment(t) }
+
+// IsPeer returns whether the tag is that of a peer relation,
+// which has a single endpoint through which the units of
+// a service relate to each other.
+func (t RelationTag) IsPeer() bool {
+	return validPeerRelation.MatchString(t.Id())
+}
+
+// Endpoints returns the endpoints of the relation, in the order
+// they appear in its key. Peer relations have a single endpoint.
+// Roles are inferred from the canonical order of endpoints in keys
+// (see RelationKeyFromEndpoints): the endpoint of a peer relation is
+// a peer, and otherwise the requirer comes before the provider.
+func (t RelationTag) Endpoints() []Endpoint {
+	var endpoints []Endpoint
+	for _, ep := range strings.Split(t.Id(), " ") {
+		i := strings.Index(ep, ":")
+		if i == -1 {
+			continue
+		}
+		endpoints = append(endpoints, Endpoint{
+			Service:  ep[:i],
+			Relation: ep[i+1:],
+		})
+	}
+	switch len(endpoints) {
+	case 1:
+		endpoints[0].Role = RolePeer
+	case 2:
+		endpoints[0].Role = RoleRequirer
+		endpoints[1].Role = RoleProvider
+	}
+	return endpoints
+}
 
 // NewRelationTag returns the tag for the relation with the given key.
 func NewRelationTag(relationKey string) RelationTag {
@@ -47,6 +114,72 @@ func NewRelationTag(relationKey string) RelationTag {
 	return RelationTag{key: relationKey}
 }
 
+// RelationKeyFromEndpoints returns the key of the relation between
+// the given endpoints: a single peer endpoint for a peer relation,
+// or a requirer and a provider otherwise. The endpoints are ordered
+// as juju orders them, requirer before provider, regardless of the
+// order in which they are given, so that every relation has a
+// single key.
+func RelationKeyFromEndpoints(endpoints ...Endpoint) (string, error) {
+	switch len(endpoints) {
+	case 1:
+		if endpoints[0].Role != RolePeer {
+			return "", fmt.Errorf("endpoint %q of a peer relation has role %q", endpoints[0], endpoints[0].Role)
+		}
+	case 2:
+		if endpoints[0] == endpoints[1] {
+			return "", fmt.Errorf("cannot relate endpoint %q to itself", endpoints[0])
+		}
+		r0, r1 := endpoints[0].Role, endpoints[1].Role
+		if !(r0 == RoleRequirer && r1 == RoleProvider || r0 == RoleProvider && r1 == RoleRequirer) {
+			return "", fmt.Errorf("cannot relate endpoints %q and %q with roles %q and %q", endpoints[0], endpoints[1], r0, r1)
+		}
+	default:
+		return "", fmt.Errorf("relation must have 1 or 2 endpoints, got %d", len(endpoints))
+	}
+	sorted := make([]Endpoint, len(endpoints))
+	copy(sorted, endpoints)
+	sort.Sort(endpointsByRole(sorted))
+	key := sorted[0].String()
+	if len(sorted) == 2 {
+		key += " " + sorted[1].String()
+	}
+	if !IsValidRelation(key) {
+		return "", fmt.Errorf("%q is not a valid relation key", key)
+	}
+	return key, nil
+}
+
+// NewRelationTagFromEndpoints returns the tag of the relation
+// between the given endpoints. See RelationKeyFromEndpoints.
+func NewRelationTagFromEndpoints(endpoints ...Endpoint) (RelationTag, error) {
+	key, err := RelationKeyFromEndpoints(endpoints...)
+	if err != nil {
+		return RelationTag{}, err
+	}
+	return NewRelationTag(key), nil
+}
+
+// roleOrder holds the order of endpoints in relation keys by role.
+var roleOrder = map[RelationRole]int{
+	RoleRequirer: 0,
+	RoleProvider: 1,
+	RolePeer:     2,
+}
+
+// endpointsByRole sorts endpoints as juju orders them in
+// relation keys: by role, then by their string form.
+type endpointsByRole []Endpoint
+
+func (s endpointsByRole) Len() int      { return len(s) }
+func (s endpointsByRole) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s endpointsByRole) Less(i, j int) bool {
+	if s[i].Role != s[j].Role {
+		return roleOrder[s[i].Role] < roleOrder[s[j].Role]
+	}
+	return s[i].String() < s[j].String()
+}
+
 // ParseRelationTag parses a relation tag string.
 func ParseRelationTag(relationTag string) (RelationTag, error) {
 	tag, err := ParseTag(relationTag)
@@ -4,6 +4,9 @@
 package names_test
 
 import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/names"
@@ -60,6 +63,13 @@ func (s *relationSuite) TestRelationKeyFormats(c *gc.C) {
 	}
 }
 
+func (s *relationSuite) TestIsValidRelationEndpointName(c *gc.C) {
+	for i, test := range relationNameTests {
+		c.Logf("test %d: %q", i, test.pattern)
+		c.Check(names.IsValidRelationEndpointName(test.pattern), gc.Equals, test.valid)
+	}
+}
+
 var parseRelationTagTests = []struct {
 	tag      string
 	expected names.Tag
@@ -93,3 +103,118 @@ func (s *relationSuite) TestParseRelationTag(c *gc.C) {
 		c.Check(got, gc.Equals, t.expected)
 	}
 }
+
+func (s *relationSuite) TestRelationEndpoints(c *gc.C) {
+	for i, test := range []struct {
+		key    string
+		expect []names.Endpoint
+	}{{
+		key: "wordpress:db mysql:server",
+		expect: []names.Endpoint{
+			{Service: "wordpress", Relation: "db", Role: names.RoleRequirer},
+			{Service: "mysql", Relation: "server", Role: names.RoleProvider},
+		},
+	}, {
+		key: "my-svc1:my_rel1 other-svc:other-rel2",
+		expect: []names.Endpoint{
+			{Service: "my-svc1", Relation: "my_rel1", Role: names.RoleRequirer},
+			{Service: "other-svc", Relation: "other-rel2", Role: names.RoleProvider},
+		},
+	}, {
+		key:    "riak:ring",
+		expect: []names.Endpoint{{Service: "riak", Relation: "ring", Role: names.RolePeer}},
+	}} {
+		c.Logf("test %d: %q", i, test.key)
+		endpoints := names.NewRelationTag(test.key).Endpoints()
+		c.Check(endpoints, jc.DeepEquals, test.expect)
+		var keys []string
+		for _, ep := range endpoints {
+			keys = append(keys, ep.String())
+		}
+		c.Check(strings.Join(keys, " "), gc.Equals, test.key)
+	}
+	c.Check(names.RelationTag{}.Endpoints(), gc.HasLen, 0)
+}
+
+func (s *relationSuite) TestNewRelationTagFromEndpoints(c *gc.C) {
+	wordpress := names.Endpoint{Service: "wordpress", Relation: "db", Role: names.RoleRequirer}
+	mysql := names.Endpoint{Service: "mysql", Relation: "server", Role: names.RoleProvider}
+	for i, test := range []struct {
+		endpoints []names.Endpoint
+		expect    string
+		err       string
+	}{{
+		endpoints: []names.Endpoint{wordpress, mysql},
+		expect:    "wordpress:db mysql:server",
+	}, {
+		endpoints: []names.Endpoint{mysql, wordpress},
+		expect:    "wordpress:db mysql:server",
+	}, {
+		endpoints: []names.Endpoint{
+			{Service: "mysql", Relation: "a", Role: names.RoleProvider},
+			{Service: "mysql", Relation: "b", Role: names.RoleRequirer},
+		},
+		expect: "mysql:b mysql:a",
+	}, {
+		endpoints: []names.Endpoint{{Service: "riak", Relation: "ring", Role: names.RolePeer}},
+		expect:    "riak:ring",
+	}, {
+		endpoints: nil,
+		err:       "relation must have 1 or 2 endpoints, got 0",
+	}, {
+		endpoints: []names.Endpoint{wordpress, mysql, wordpress},
+		err:       "relation must have 1 or 2 endpoints, got 3",
+	}, {
+		endpoints: []names.Endpoint{mysql, mysql},
+		err:       `cannot relate endpoint "mysql:server" to itself`,
+	}, {
+		endpoints: []names.Endpoint{wordpress, {Service: "mysql", Relation: "server", Role: names.RoleRequirer}},
+		err:       `cannot relate endpoints "wordpress:db" and "mysql:server" with roles "requirer" and "requirer"`,
+	}, {
+		endpoints: []names.Endpoint{{Service: "wordpress", Relation: "db"}, {Service: "mysql", Relation: "server"}},
+		err:       `cannot relate endpoints "wordpress:db" and "mysql:server" with roles "" and ""`,
+	}, {
+		endpoints: []names.Endpoint{{Service: "riak", Relation: "ring", Role: names.RolePeer}, mysql},
+		err:       `cannot relate endpoints "riak:ring" and "mysql:server" with roles "peer" and "provider"`,
+	}, {
+		endpoints: []names.Endpoint{mysql},
+		err:       `endpoint "mysql:server" of a peer relation has role "provider"`,
+	}, {
+		endpoints: []names.Endpoint{{Service: "Riak", Relation: "ring", Role: names.RolePeer}},
+		err:       `"Riak:ring" is not a valid relation key`,
+	}} {
+		c.Logf("test %d: %v", i, test.endpoints)
+		tag, err := names.NewRelationTagFromEndpoints(test.endpoints...)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, names.NewRelationTag(test.expect))
+		key, err := names.RelationKeyFromEndpoints(test.endpoints...)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(key, gc.Equals, test.expect)
+
+		// The endpoints of the tag give back the same tag.
+		built, err := names.NewRelationTagFromEndpoints(tag.Endpoints()...)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(built, gc.Equals, tag)
+	}
+}
+
+func (s *relationSuite) TestPeerRelation(c *gc.C) {
+	tag, err := names.ParseRelationTag("relation-wordpress.loadbalancer")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag.Id(), gc.Equals, "wordpress:loadbalancer")
+	c.Assert(tag.IsPeer(), jc.IsTrue)
+	c.Assert(tag.Endpoints(), jc.DeepEquals, []names.Endpoint{
+		{Service: "wordpress", Relation: "loadbalancer", Role: names.RolePeer},
+	})
+
+	built, err := names.NewRelationTagFromEndpoints(tag.Endpoints()...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(built, gc.Equals, tag)
+
+	c.Assert(names.NewRelationTag("wordpress:db mysql:server").IsPeer(), jc.IsFalse)
+	c.Assert(names.RelationTag{}.IsPeer(), jc.IsFalse)
+}
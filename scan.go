@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"strings"
+)
+
+// The functions in this file validate the ids of the most frequently
+// parsed kinds of tag by scanning them byte by byte, which is much
+// faster than matching the equivalent regular expressions and does
+// not allocate. Each must accept exactly the strings matched by the
+// corresponding snippet.
+
+// scanNumber reports whether s matches NumberSnippet.
+func scanNumber(s string) bool {
+	if s == "" || s[0] == '0' && len(s) > 1 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// scanServiceName reports whether s matches ServiceSnippet: hyphen
+// separated segments of lower case letters and digits, where the
+// name starts with a letter and every segment contains a letter.
+func scanServiceName(s string) bool {
+	if s == "" || !isLower(s[0]) {
+		return false
+	}
+	hasLetter := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case isLower(c):
+			hasLetter = true
+		case isDigit(c):
+		case c == '-':
+			if !hasLetter {
+				return false
+			}
+			hasLetter = false
+		default:
+			return false
+		}
+	}
+	return hasLetter
+}
+
+// scanUnitName reports whether s matches
+// ServiceSnippet + "/" + NumberSnippet.
+func scanUnitName(s string) bool {
+	i := strings.LastIndexByte(s, '/')
+	return i != -1 && scanServiceName(s[:i]) && scanNumber(s[i+1:])
+}
+
+// scanMachineId reports whether s is a valid machine id under r.
+// It accepts exactly the ids for which r.Validate returns nil.
+func (r MachineIdRules) scanMachineId(s string) bool {
+	if s == "" {
+		return false
+	}
+	level := 0
+	for part := 0; ; part++ {
+		end := strings.IndexByte(s, '/')
+		field := s
+		if end != -1 {
+			field = s[:end]
+		}
+		if part%2 == 0 {
+			if !scanNumber(field) {
+				return false
+			}
+		} else {
+			if end == -1 || !r.scanContainerType(field) {
+				return false
+			}
+			level++
+		}
+		if end == -1 {
+			break
+		}
+		s = s[end+1:]
+	}
+	return r.MaxNestingLevel <= 0 || level <= r.MaxNestingLevel
+}
+
+// scanContainerType reports whether s matches ContainerTypeSnippet
+// and is one of the container types allowed by r.
+func (r MachineIdRules) scanContainerType(s string) bool {
+	if len(r.ContainerTypes) > 0 {
+		for _, t := range r.ContainerTypes {
+			if t == s {
+				return s != "" && scanLowers(s)
+			}
+		}
+		return false
+	}
+	return s != "" && scanLowers(s)
+}
+
+func scanLowers(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isLower(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"regexp"
+	"testing"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type scanSuite struct{}
+
+var _ = gc.Suite(&scanSuite{})
+
+var (
+	serviceRegexp = regexp.MustCompile("^" + names.ServiceSnippet + "$")
+	unitRegexp    = regexp.MustCompile("^" + names.ServiceSnippet + "/" + names.NumberSnippet + "$")
+	machineRegexp = regexp.MustCompile("^" + names.MachineSnippet + "$")
+)
+
+// allStrings calls f with every string of up to maxLen
+// bytes drawn from the given alphabet.
+func allStrings(alphabet string, maxLen int, f func(s string)) {
+	buf := make([]byte, 0, maxLen)
+	var gen func()
+	gen = func() {
+		f(string(buf))
+		if len(buf) == maxLen {
+			return
+		}
+		for i := 0; i < len(alphabet); i++ {
+			buf = append(buf, alphabet[i])
+			gen()
+			buf = buf[:len(buf)-1]
+		}
+	}
+	gen()
+}
+
+func (s *scanSuite) TestIsValidServiceMatchesRegexp(c *gc.C) {
+	allStrings("ab09-_A", 6, func(name string) {
+		if names.IsValidService(name) != serviceRegexp.MatchString(name) {
+			c.Errorf("IsValidService(%q) = %v", name, names.IsValidService(name))
+		}
+	})
+}
+
+func (s *scanSuite) TestIsValidUnitMatchesRegexp(c *gc.C) {
+	allStrings("a01-/", 7, func(name string) {
+		if names.IsValidUnit(name) != unitRegexp.MatchString(name) {
+			c.Errorf("IsValidUnit(%q) = %v", name, names.IsValidUnit(name))
+		}
+	})
+}
+
+func (s *scanSuite) TestIsValidMachineMatchesRegexp(c *gc.C) {
+	var anyContainer names.MachineIdRules
+	allStrings("01/aB-", 7, func(id string) {
+		if anyContainer.IsValid(id) != machineRegexp.MatchString(id) {
+			c.Errorf("IsValid(%q) = %v", id, anyContainer.IsValid(id))
+		}
+	})
+}
+
+func (s *scanSuite) TestMachineIdRulesIsValidMatchesValidate(c *gc.C) {
+	for _, rules := range []names.MachineIdRules{
+		names.DefaultMachineIdRules(),
+		{MaxNestingLevel: 1},
+		{MaxNestingLevel: 2, ContainerTypes: []string{"lxd"}},
+	} {
+		allStrings("01/lxd", 7, func(id string) {
+			if rules.IsValid(id) != (rules.Validate(id) == nil) {
+				c.Errorf("%+v: IsValid(%q) = %v", rules, id, rules.IsValid(id))
+			}
+		})
+	}
+}
+
+var benchmarkUnitName = "rabbitmq-server/123"
+
+func BenchmarkIsValidUnit(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		names.IsValidUnit(benchmarkUnitName)
+	}
+}
+
+func BenchmarkUnitRegexp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		unitRegexp.MatchString(benchmarkUnitName)
+	}
+}
+
+var benchmarkServiceName = "rabbitmq-server"
+
+func BenchmarkIsValidService(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		names.IsValidService(benchmarkServiceName)
+	}
+}
+
+func BenchmarkServiceRegexp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		serviceRegexp.MatchString(benchmarkServiceName)
+	}
+}
+
+var benchmarkMachineId = "0/lxd/12/kvm/3"
+
+func BenchmarkIsValidMachine(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		names.IsValidMachine(benchmarkMachineId)
+	}
+}
+
+func BenchmarkMachineRegexp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		machineRegexp.MatchString(benchmarkMachineId)
+	}
+}
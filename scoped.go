@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScopedTag identifies an entity within a model, for use where
+// entities of more than one model are handled together, as in
+// multi-model controllers.
+type ScopedTag struct {
+	Model  ModelTag
+	Entity Tag
+}
+
+// String returns the canonical string form of the scoped tag,
+// "<model-uuid>:<tag>", as used for entity document ids
+// (see EntityDocID).
+func (t ScopedTag) String() string {
+	return scopedString(t.Model.Id(), t.Entity)
+}
+
+// Validate returns an error if the model or entity tag
+// is not valid.
+func (t ScopedTag) Validate() error {
+	return validateScoped(t.Model, t.Entity)
+}
+
+// ParseScopedTag parses the string form of a scoped tag, as returned
+// by ScopedTag.String. Model UUIDs in upper or mixed case are
+// accepted, as they are in model tags.
+func ParseScopedTag(s string) (ScopedTag, error) {
+	modelUUID, tag, err := parseUUIDScoped(lowerScopeUUID(s), "scoped tag", ModelTagKind, IsValidModel)
+	if err != nil {
+		return ScopedTag{}, err
+	}
+	return ScopedTag{
+		Model:  NewModelTag(modelUUID),
+		Entity: tag,
+	}, nil
+}
+
+// ControllerScopedTag identifies an entity within a controller, for
+// use by tools that handle the entities of several controllers
+// together. The entity is typically a model, or a user or other
+// entity with controller-wide scope.
+type ControllerScopedTag struct {
+	Controller ControllerTag
+	Entity     Tag
+}
+
+// String returns the canonical string form of the controller-scoped
+// tag, "<controller-uuid>:<tag>". It has the same syntax as the string
+// form of a ScopedTag, so the two can only be told apart by context.
+func (t ControllerScopedTag) String() string {
+	return scopedString(t.Controller.Id(), t.Entity)
+}
+
+// Validate returns an error if the controller or entity tag
+// is not valid.
+func (t ControllerScopedTag) Validate() error {
+	return validateScoped(t.Controller, t.Entity)
+}
+
+// ParseControllerScopedTag parses the string form of a
+// controller-scoped tag, as returned by ControllerScopedTag.String.
+func ParseControllerScopedTag(s string) (ControllerScopedTag, error) {
+	controllerUUID, tag, err := parseUUIDScoped(lowerScopeUUID(s), "controller-scoped tag", ControllerTagKind, IsValidController)
+	if err != nil {
+		return ControllerScopedTag{}, err
+	}
+	return ControllerScopedTag{
+		Controller: NewControllerTag(controllerUUID),
+		Entity:     tag,
+	}, nil
+}
+
+func scopedString(uuid string, entity Tag) string {
+	if isNilTag(entity) {
+		return uuid + ":"
+	}
+	return EntityDocID(uuid, entity)
+}
+
+func validateScoped(scope, entity Tag) error {
+	if err := Validate(scope); err != nil {
+		return err
+	}
+	if isNilTag(entity) {
+		return fmt.Errorf("scoped tag has no entity")
+	}
+	return Validate(entity)
+}
+
+// lowerScopeUUID converts the UUID before the first ":" in s to
+// lower case, if it is a UUID written in upper or mixed case.
+func lowerScopeUUID(s string) string {
+	if i := strings.Index(s, ":"); i != -1 {
+		return lowerUUID(s[:i]) + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type scopedSuite struct{}
+
+var _ = gc.Suite(&scopedSuite{})
+
+func (s *scopedSuite) TestString(c *gc.C) {
+	t := names.ScopedTag{
+		Model:  names.NewModelTag(docModelUUID),
+		Entity: names.NewUnitTag("mysql/0"),
+	}
+	c.Assert(t.String(), gc.Equals, docModelUUID+":unit-mysql-0")
+	c.Assert(t.String(), gc.Equals, names.EntityDocID(docModelUUID, t.Entity))
+}
+
+func (s *scopedSuite) TestParseScopedTag(c *gc.C) {
+	for i, test := range []struct {
+		str    string
+		entity names.Tag
+		err    string
+	}{{
+		str:    docModelUUID + ":machine-0-lxc-1",
+		entity: names.NewMachineTag("0/lxc/1"),
+	}, {
+		str:    docModelUUID + ":charm-cs:trusty/mysql-1",
+		entity: names.NewCharmTag("cs:trusty/mysql-1"),
+	}, {
+		str:    strings.ToUpper(docModelUUID) + ":user-bob",
+		entity: names.NewUserTag("bob"),
+	}, {
+		str:    docModelUUID + ":model-" + docModelUUID,
+		entity: names.NewModelTag(docModelUUID),
+	}, {
+		str: "unit-mysql-0",
+		err: `"unit-mysql-0" is not a valid scoped tag`,
+	}, {
+		str: "foo:unit-mysql-0",
+		err: `"foo:unit-mysql-0" is not a valid scoped tag: invalid model UUID "foo"`,
+	}, {
+		str: docModelUUID + ":",
+		err: `".*:" is not a valid scoped tag: "" is not a valid tag`,
+	}, {
+		str: docModelUUID + ":unit-mysql",
+		err: `".*:unit-mysql" is not a valid scoped tag: "unit-mysql" is not a valid unit tag`,
+	}} {
+		c.Logf("test %d: %q", i, test.str)
+		t, err := names.ParseScopedTag(test.str)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(t.Model, gc.Equals, names.NewModelTag(docModelUUID))
+		c.Check(t.Entity, gc.Equals, test.entity)
+		c.Check(t.Validate(), jc.ErrorIsNil)
+		c.Check(t.String(), gc.Equals, docModelUUID+":"+test.entity.String())
+	}
+}
+
+func (s *scopedSuite) TestValidate(c *gc.C) {
+	t := names.ScopedTag{Model: names.NewModelTag(docModelUUID)}
+	c.Check(t.Validate(), gc.ErrorMatches, "scoped tag has no entity")
+	c.Check(t.String(), gc.Equals, docModelUUID+":")
+
+	t.Entity = names.NewUnitTag("mysql/0")
+	c.Check(t.Validate(), jc.ErrorIsNil)
+
+	t.Model = names.ModelTag{}
+	c.Check(t.Validate(), gc.NotNil)
+}
+
+func (s *scopedSuite) TestControllerScopedString(c *gc.C) {
+	t := names.ControllerScopedTag{
+		Controller: names.NewControllerTag(controllerUUID),
+		Entity:     names.NewModelTag(docModelUUID),
+	}
+	c.Assert(t.String(), gc.Equals, controllerUUID+":model-"+docModelUUID)
+	c.Assert(t.Validate(), jc.ErrorIsNil)
+}
+
+func (s *scopedSuite) TestParseControllerScopedTag(c *gc.C) {
+	for i, test := range []struct {
+		str    string
+		entity names.Tag
+		err    string
+	}{{
+		str:    controllerUUID + ":model-" + docModelUUID,
+		entity: names.NewModelTag(docModelUUID),
+	}, {
+		str:    strings.ToUpper(controllerUUID) + ":user-bob@external",
+		entity: names.NewUserTag("bob@external"),
+	}, {
+		str: "model-" + docModelUUID,
+		err: `"model-.*" is not a valid controller-scoped tag`,
+	}, {
+		str: "x" + controllerUUID + ":user-bob",
+		err: `"x.*:user-bob" is not a valid controller-scoped tag: invalid controller UUID "x.*"`,
+	}, {
+		str: controllerUUID + ":model-foo",
+		err: `".*:model-foo" is not a valid controller-scoped tag: "model-foo" is not a valid model tag`,
+	}} {
+		c.Logf("test %d: %q", i, test.str)
+		t, err := names.ParseControllerScopedTag(test.str)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(t.Controller, gc.Equals, names.NewControllerTag(controllerUUID))
+		c.Check(t.Entity, gc.Equals, test.entity)
+		c.Check(t.String(), gc.Equals, controllerUUID+":"+test.entity.String())
+	}
+}
+
+func (s *scopedSuite) TestControllerScopedValidate(c *gc.C) {
+	t := names.ControllerScopedTag{Entity: names.NewUserTag("bob")}
+	c.Check(t.Validate(), gc.NotNil)
+
+	t.Controller = names.NewControllerTag(controllerUUID)
+	c.Check(t.Validate(), jc.ErrorIsNil)
+
+	t.Entity = nil
+	c.Check(t.Validate(), gc.ErrorMatches, "scoped tag has no entity")
+}
@@ -3,10 +3,6 @@
 
 package names
 
-import (
-	"regexp"
-)
-
 const ServiceTagKind = "service"
 
 const (
@@ -14,20 +10,20 @@ const (
 	NumberSnippet  = "(?:0|[1-9][0-9]*)"
 )
 
-var validService = regexp.MustCompile("^" + ServiceSnippet + "$")
-
 // IsValidService returns whether name is a valid service name.
 func IsValidService(name string) bool {
-	return validService.MatchString(name)
+	return scanServiceName(name)
 }
 
 type ServiceTag struct {
 	Name string
 }
 
-func (t ServiceTag) String() string { return t.Kind() + "-" + t.Id() }
-func (t ServiceTag) Kind() string   { return ServiceTagKind }
-func (t ServiceTag) Id() string     { return t.Name }
+func (t ServiceTag) String() string      { return t.Kind() + "-" + t.Id() }
+func (t ServiceTag) Kind() string        { return ServiceTagKind }
+func (t ServiceTag) Id() string          { return t.Name }
+func (t ServiceTag) Validate() error     { return validateTag(t) }
+func (t ServiceTag) PathSegment() string { return pathSegment(t) }
 
 // NewServiceTag returns the tag for the service with the given name.
 func NewServiceTag(serviceName string) ServiceTag {
@@ -46,3 +42,9 @@ func ParseServiceTag(serviceTag string) (ServiceTag, error) {
 	}
 	return st, nil
 }
+
+// ServiceIdFromTagString returns the name of
+// the service with the given tag string.
+func ServiceIdFromTagString(s string) (string, error) {
+	return idFromTagString(ServiceTagKind, s)
+}
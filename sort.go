@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"sort"
+	"strings"
+)
+
+// sortKeyNumberWidth holds the width to which numbers are padded
+// in sort keys; it is enough for any 64 bit integer.
+const sortKeyNumberWidth = 20
+
+// sortKeyNumber returns the decimal number n padded with leading
+// zeros, so that numbers compare in numeric order as strings.
+func sortKeyNumber(n string) string {
+	if len(n) >= sortKeyNumberWidth {
+		return n
+	}
+	return strings.Repeat("0", sortKeyNumberWidth-len(n)) + n
+}
+
+// SortMachineTags sorts the given machine tags in natural order
+// (see MachineTag.SortKey).
+func SortMachineTags(tags []MachineTag) {
+	sort.Sort(machineTagsByKey(tags))
+}
+
+type machineTagsByKey []MachineTag
+
+func (s machineTagsByKey) Len() int           { return len(s) }
+func (s machineTagsByKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s machineTagsByKey) Less(i, j int) bool { return s[i].SortKey() < s[j].SortKey() }
+
+// SortUnitTags sorts the given unit tags in natural order
+// (see UnitTag.SortKey).
+func SortUnitTags(tags []UnitTag) {
+	sort.Sort(unitTagsByKey(tags))
+}
+
+type unitTagsByKey []UnitTag
+
+func (s unitTagsByKey) Len() int           { return len(s) }
+func (s unitTagsByKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s unitTagsByKey) Less(i, j int) bool { return s[i].SortKey() < s[j].SortKey() }
+
+// SortVolumeTags sorts the given volume tags in natural order
+// (see VolumeTag.SortKey).
+func SortVolumeTags(tags []VolumeTag) {
+	sort.Sort(volumeTagsByKey(tags))
+}
+
+type volumeTagsByKey []VolumeTag
+
+func (s volumeTagsByKey) Len() int           { return len(s) }
+func (s volumeTagsByKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s volumeTagsByKey) Less(i, j int) bool { return s[i].SortKey() < s[j].SortKey() }
+
+// SortFilesystemTags sorts the given filesystem tags in natural order
+// (see FilesystemTag.SortKey).
+func SortFilesystemTags(tags []FilesystemTag) {
+	sort.Sort(filesystemTagsByKey(tags))
+}
+
+type filesystemTagsByKey []FilesystemTag
+
+func (s filesystemTagsByKey) Len() int           { return len(s) }
+func (s filesystemTagsByKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s filesystemTagsByKey) Less(i, j int) bool { return s[i].SortKey() < s[j].SortKey() }
@@ -0,0 +1,83 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type sortSuite struct{}
+
+var _ = gc.Suite(&sortSuite{})
+
+func machineTags(ids ...string) []names.MachineTag {
+	tags := make([]names.MachineTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewMachineTag(id)
+	}
+	return tags
+}
+
+func (s *sortSuite) TestSortMachineTags(c *gc.C) {
+	tags := machineTags("10", "2/lxd/10", "2", "1", "2/lxd/2", "2/kvm/0", "2/lxd/2/lxd/0", "0")
+	names.SortMachineTags(tags)
+	c.Assert(tags, jc.DeepEquals, machineTags(
+		"0", "1", "2", "2/kvm/0", "2/lxd/2", "2/lxd/2/lxd/0", "2/lxd/10", "10",
+	))
+}
+
+func unitTags(ids ...string) []names.UnitTag {
+	tags := make([]names.UnitTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewUnitTag(id)
+	}
+	return tags
+}
+
+func (s *sortSuite) TestSortUnitTags(c *gc.C) {
+	tags := unitTags("mysql/10", "mysql-router/0", "mysql/9", "haproxy/1", "mysql/0")
+	names.SortUnitTags(tags)
+	c.Assert(tags, jc.DeepEquals, unitTags(
+		"haproxy/1", "mysql/0", "mysql/9", "mysql/10", "mysql-router/0",
+	))
+}
+
+func volumeTags(ids ...string) []names.VolumeTag {
+	tags := make([]names.VolumeTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	return tags
+}
+
+func (s *sortSuite) TestSortVolumeTags(c *gc.C) {
+	tags := volumeTags(
+		"mysql/0/1", "2/0", "10", "0/lxd/1/0", "5", "0/10", "mysql/0/0",
+		"0/5", "haproxy/10/0", "haproxy/2/0",
+	)
+	names.SortVolumeTags(tags)
+	c.Assert(tags, jc.DeepEquals, volumeTags(
+		"5", "10", "0/5", "0/10", "0/lxd/1/0", "2/0",
+		"haproxy/2/0", "haproxy/10/0", "mysql/0/0", "mysql/0/1",
+	))
+}
+
+func filesystemTags(ids ...string) []names.FilesystemTag {
+	tags := make([]names.FilesystemTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewFilesystemTag(id)
+	}
+	return tags
+}
+
+func (s *sortSuite) TestSortFilesystemTags(c *gc.C) {
+	tags := filesystemTags("mysql/0/0", "10/1", "2/1", "10", "2", "2/0")
+	names.SortFilesystemTags(tags)
+	c.Assert(tags, jc.DeepEquals, filesystemTags(
+		"2", "10", "2/0", "2/1", "10/1", "mysql/0/0",
+	))
+}
@@ -5,7 +5,7 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"strings"
 )
 
 const (
@@ -13,22 +13,110 @@ const (
 	SpaceSnippet = "(?:[a-z0-9]+(?:-[a-z0-9]+)*)"
 )
 
-var validSpace = regexp.MustCompile("^" + SpaceSnippet + "$")
+var validSpace = newLazyRegexp("^" + SpaceSnippet + "$")
 
 // IsValidSpace reports whether name is a valid space name.
 func IsValidSpace(name string) bool {
 	return validSpace.MatchString(name)
 }
 
+// MaxStrictSpaceNameLength is the maximum length of a space
+// name under StrictSpaceNames; it is the length of a DNS label.
+const MaxStrictSpaceNameLength = 63
+
+// SpaceNameProfile selects the rules by which space names are validated.
+type SpaceNameProfile int
+
+const (
+	// LegacySpaceNames applies the rules of IsValidSpace.
+	LegacySpaceNames SpaceNameProfile = iota
+
+	// StrictSpaceNames applies rules aligned with RFC 1123 host
+	// name labels, as space names become DNS labels on some
+	// substrates. In addition to the legacy rules, names must be
+	// at most MaxStrictSpaceNameLength characters long and must
+	// not consist only of digits, which would be taken to be a
+	// space ID.
+	StrictSpaceNames
+)
+
+// IsValidName returns whether name is a valid
+// space name under the profile.
+func (p SpaceNameProfile) IsValidName(name string) bool {
+	return p.Validate(name) == nil
+}
+
+// Validate returns an error describing why name is not a
+// valid space name under the profile, or nil if it is valid.
+func (p SpaceNameProfile) Validate(name string) error {
+	if !IsValidSpace(name) {
+		return fmt.Errorf("%q is not a valid space name", name)
+	}
+	if p != StrictSpaceNames {
+		return nil
+	}
+	if len(name) > MaxStrictSpaceNameLength {
+		return fmt.Errorf("space name %q is longer than %d characters", name, MaxStrictSpaceNameLength)
+	}
+	if isAllDigits(name) {
+		return fmt.Errorf("space name %q consists only of digits", name)
+	}
+	return nil
+}
+
+// ToStrictSpaceName converts a name valid under LegacySpaceNames to
+// one valid under StrictSpaceNames. If the name is not valid under
+// StrictSpaceNames, the converted name is returned together with an
+// error describing why the original name is not valid. If the name
+// is not a valid legacy name, it cannot be converted and an empty
+// name is returned.
+func ToStrictSpaceName(name string) (string, error) {
+	if !IsValidSpace(name) {
+		return "", LegacySpaceNames.Validate(name)
+	}
+	err := StrictSpaceNames.Validate(name)
+	if err == nil {
+		return name, nil
+	}
+	strict := name
+	if isAllDigits(strict) {
+		strict = "space-" + strict
+	}
+	if len(strict) > MaxStrictSpaceNameLength {
+		strict = strings.TrimRight(strict[:MaxStrictSpaceNameLength], "-")
+	}
+	return strict, err
+}
+
+// SpaceTag represents a tag used to describe a space. Spaces are
+// identified either by name or by numeric ID; ids consisting only
+// of digits are always taken to be IDs.
 type SpaceTag struct {
 	name string
 }
 
-func (t SpaceTag) String() string { return t.Kind() + "-" + t.Id() }
-func (t SpaceTag) Kind() string   { return SpaceTagKind }
-func (t SpaceTag) Id() string     { return t.name }
+func (t SpaceTag) String() string      { return t.Kind() + "-" + t.Id() }
+func (t SpaceTag) Kind() string        { return SpaceTagKind }
+func (t SpaceTag) Id() string          { return t.name }
+func (t SpaceTag) Validate() error     { return validateTag(t) }
+func (t SpaceTag) PathSegment() string { return pathSegment(t) }
 
-// NewSpaceTag returns the tag of a space with the given name.
+// IsID returns whether the space is identified by numeric ID,
+// that is, whether its id consists only of digits.
+func (t SpaceTag) IsID() bool {
+	return t.name != "" && isAllDigits(t.name)
+}
+
+// Name returns the name of the space, or the empty
+// string if the space is identified by numeric ID.
+func (t SpaceTag) Name() string {
+	if t.IsID() {
+		return ""
+	}
+	return t.name
+}
+
+// NewSpaceTag returns the tag of a space with the given name or ID.
 func NewSpaceTag(name string) SpaceTag {
 	if !IsValidSpace(name) {
 		panic(fmt.Sprintf("%q is not a valid space name", name))
@@ -48,3 +136,7 @@ func ParseSpaceTag(spaceTag string) (SpaceTag, error) {
 	}
 	return nt, nil
 }
+
+func isAllDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
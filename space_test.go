@@ -6,6 +6,7 @@ package names_test
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	gc "gopkg.in/check.v1"
 
@@ -78,3 +79,91 @@ func (s *spaceSuite) TestParseSpaceTag(c *gc.C) {
 		c.Check(got, gc.Equals, t.expected)
 	}
 }
+
+func (s *spaceSuite) TestSpaceTagIDAndName(c *gc.C) {
+	for i, test := range []struct {
+		id   string
+		isID bool
+		name string
+	}{
+		{"42", true, ""},
+		{"0", true, ""},
+		{"042", true, ""},
+		{"01", true, ""},
+		{"db", false, "db"},
+		{"my-space-2", false, "my-space-2"},
+	} {
+		c.Logf("test %d: %q", i, test.id)
+		tag, err := names.ParseSpaceTag("space-" + test.id)
+		c.Assert(err, gc.IsNil)
+		c.Check(tag.IsID(), gc.Equals, test.isID)
+		c.Check(tag.Name(), gc.Equals, test.name)
+		c.Check(tag.Id(), gc.Equals, test.id)
+	}
+	c.Check(names.SpaceTag{}.IsID(), gc.Equals, false)
+}
+
+func (s *spaceSuite) TestSpaceNameProfiles(c *gc.C) {
+	long := strings.Repeat("a", 64)
+	for i, test := range []struct {
+		name      string
+		legacyErr string
+		strictErr string
+	}{
+		{name: "db"},
+		{name: "my-space-2"},
+		{name: strings.Repeat("a", 63)},
+		{name: long, strictErr: `space name "a{64}" is longer than 63 characters`},
+		{name: "42", strictErr: `space name "42" consists only of digits`},
+		{name: "042", strictErr: `space name "042" consists only of digits`},
+		{name: "", legacyErr: `"" is not a valid space name`, strictErr: `"" is not a valid space name`},
+		{name: "-db", legacyErr: `"-db" is not a valid space name`, strictErr: `"-db" is not a valid space name`},
+		{name: "Db", legacyErr: `"Db" is not a valid space name`, strictErr: `"Db" is not a valid space name`},
+	} {
+		c.Logf("test %d: %q", i, test.name)
+		for _, p := range []struct {
+			profile names.SpaceNameProfile
+			err     string
+		}{
+			{names.LegacySpaceNames, test.legacyErr},
+			{names.StrictSpaceNames, test.strictErr},
+		} {
+			err := p.profile.Validate(test.name)
+			c.Check(p.profile.IsValidName(test.name), gc.Equals, p.err == "")
+			if p.err == "" {
+				c.Check(err, gc.IsNil)
+			} else {
+				c.Check(err, gc.ErrorMatches, p.err)
+			}
+		}
+	}
+}
+
+func (s *spaceSuite) TestToStrictSpaceName(c *gc.C) {
+	for i, test := range []struct {
+		name   string
+		expect string
+		err    string
+	}{
+		{name: "db", expect: "db"},
+		{name: "42", expect: "space-42", err: `space name "42" consists only of digits`},
+		{
+			name:   strings.Repeat("a", 62) + "-b",
+			expect: strings.Repeat("a", 62),
+			err:    `space name "a{62}-b" is longer than 63 characters`,
+		},
+		{name: "-db", err: `"-db" is not a valid space name`},
+	} {
+		c.Logf("test %d: %q", i, test.name)
+		strict, err := names.ToStrictSpaceName(test.name)
+		c.Check(strict, gc.Equals, test.expect)
+		if test.err == "" {
+			c.Check(err, gc.IsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+		if strict != "" {
+			c.Check(names.StrictSpaceNames.IsValidName(strict), gc.Equals, true)
+		}
+	}
+}
@@ -5,7 +5,7 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -17,15 +17,36 @@ const (
 	StorageNameSnippet = "(?:[a-z][a-z0-9]*(?:-[a-z0-9]*[a-z][a-z0-9]*)*)"
 )
 
-var validStorage = regexp.MustCompile("^(" + StorageNameSnippet + ")/" + NumberSnippet + "$")
+var validStorage = newLazyRegexp("^(" + StorageNameSnippet + ")/" + NumberSnippet + "$")
 
 type StorageTag struct {
 	id string
 }
 
-func (t StorageTag) String() string { return t.Kind() + "-" + t.id }
-func (t StorageTag) Kind() string   { return StorageTagKind }
-func (t StorageTag) Id() string     { return storageTagSuffixToId(t.id) }
+func (t StorageTag) String() string      { return t.Kind() + "-" + t.id }
+func (t StorageTag) Kind() string        { return StorageTagKind }
+func (t StorageTag) Id() string          { return storageTagSuffixToId(t.id) }
+func (t StorageTag) Validate() error     { return validateTag(t) }
+func (t StorageTag) PathSegment() string { return pathSegment(t) }
+
+// StorageName returns the name of the storage the instance
+// belongs to, e.g. "data" for storage instance data/3.
+func (t StorageTag) StorageName() string {
+	i := strings.LastIndex(t.id, "-")
+	if i == -1 {
+		return ""
+	}
+	return t.id[:i]
+}
+
+// Ordinal returns the sequence number of the storage
+// instance, e.g. 3 for storage instance data/3.
+func (t StorageTag) Ordinal() int {
+	// The id is validated on construction, so
+	// the conversion cannot fail for valid tags.
+	n, _ := strconv.Atoi(t.id[strings.LastIndex(t.id, "-")+1:])
+	return n
+}
 
 // NewStorageTag returns the tag for the storage instance with the given ID.
 // It will panic if the given string is not a valid storage instance Id.
@@ -37,6 +58,19 @@ func NewStorageTag(id string) StorageTag {
 	return tag
 }
 
+// NewStorageTagFromParts returns the tag for storage
+// instance number ordinal of the storage with the given name.
+func NewStorageTagFromParts(name string, ordinal int) (StorageTag, error) {
+	if ordinal < 0 {
+		return StorageTag{}, fmt.Errorf("%d is not a valid storage instance ordinal", ordinal)
+	}
+	tag, ok := tagFromStorageId(name + "/" + strconv.Itoa(ordinal))
+	if !ok {
+		return StorageTag{}, fmt.Errorf("%q is not a valid storage name", name)
+	}
+	return tag, nil
+}
+
 // ParseStorageTag parses a storage tag string.
 func ParseStorageTag(s string) (StorageTag, error) {
 	tag, err := ParseTag(s)
@@ -66,6 +100,36 @@ func StorageName(id string) (string, error) {
 	return s[1], nil
 }
 
+// ParseStorageOwnerTag parses the tag string of a storage instance
+// owner. Storage instances are owned either by a unit, for storage
+// that lives and dies with the unit, or by a service, for storage
+// shared by all of its units.
+func ParseStorageOwnerTag(s string) (Tag, error) {
+	tag, err := ParseTag(s)
+	if err != nil {
+		return nil, err
+	}
+	switch tag.(type) {
+	case UnitTag, ServiceTag:
+		return tag, nil
+	}
+	return nil, fmt.Errorf("%q is not a valid storage owner tag", s)
+}
+
+// StorageOwnerUnit returns the unit owning storage with the
+// given owner tag, and whether the storage is unit-owned.
+func StorageOwnerUnit(owner Tag) (UnitTag, bool) {
+	tag, ok := owner.(UnitTag)
+	return tag, ok
+}
+
+// StorageOwnerService returns the service owning storage with
+// the given owner tag, and whether the storage is service-owned.
+func StorageOwnerService(owner Tag) (ServiceTag, bool) {
+	tag, ok := owner.(ServiceTag)
+	return tag, ok
+}
+
 func tagFromStorageId(id string) (StorageTag, bool) {
 	// replace only the last "/" with "-".
 	i := strings.LastIndex(id, "/")
@@ -84,3 +148,68 @@ func storageTagSuffixToId(s string) string {
 	}
 	return s
 }
+
+// isUnitScopedStorageId returns whether the given volume or
+// filesystem id is bound to a unit. Unit-scoped ids start
+// with a service name, whereas machine-scoped and unscoped
+// ids start with a number.
+func isUnitScopedStorageId(id string) bool {
+	return id != "" && id[0] >= 'a' && id[0] <= 'z'
+}
+
+// isValidScopedStorageId returns whether the given volume or
+// filesystem id matches re and, if it is bound to a machine,
+// whether the machine id is valid (see IsValidMachine).
+func isValidScopedStorageId(re *lazyRegexp, id string) bool {
+	if !re.MatchString(id) {
+		return false
+	}
+	if i := strings.LastIndex(id, "/"); i != -1 && !isUnitScopedStorageId(id) {
+		return IsValidMachine(id[:i])
+	}
+	return true
+}
+
+// scopedStorageUnit returns the unit the given volume or filesystem
+// id is bound to, and whether the id is bound to a unit.
+func scopedStorageUnit(id string) (UnitTag, bool) {
+	if !isUnitScopedStorageId(id) {
+		return UnitTag{}, false
+	}
+	return NewUnitTag(id[:strings.LastIndex(id, "/")]), true
+}
+
+// scopedStorageSortKey returns the sort key of the given volume or
+// filesystem id. Unscoped ids come first, then machine-scoped ids
+// ordered by machine, then unit-scoped ids ordered by unit; ids with
+// the same scope are ordered numerically.
+func scopedStorageSortKey(id string) string {
+	i := strings.LastIndex(id, "/")
+	number := sortKeyNumber(id[i+1:])
+	switch {
+	case i == -1:
+		return "0 " + number
+	case isUnitScopedStorageId(id):
+		return "2 " + NewUnitTag(id[:i]).SortKey() + "/" + number
+	}
+	return "1 " + NewMachineTag(id[:i]).SortKey() + "/" + number
+}
+
+// scopedStorageTagSuffixToId converts the suffix of a volume or
+// filesystem tag to its id.
+func scopedStorageTagSuffixToId(s string) string {
+	if !isUnitScopedStorageId(s) {
+		return strings.Replace(s, "-", "/", -1)
+	}
+	// Service names may contain hyphens, so replace
+	// only the last two "-" with "/".
+	i := strings.LastIndex(s, "-")
+	if i <= 0 {
+		return s
+	}
+	j := strings.LastIndex(s[:i], "-")
+	if j <= 0 {
+		return s[:i] + "/" + s[i+1:]
+	}
+	return s[:j] + "/" + s[j+1:i] + "/" + s[i+1:]
+}
@@ -38,6 +38,52 @@ func (s *storageSuite) TestParseStorageTag(c *gc.C) {
 	assertParseStorageTagInvalid(c, "machine-0", names.InvalidTagError("machine-0", names.StorageTagKind))
 }
 
+func (s *storageSuite) TestStorageTagParts(c *gc.C) {
+	tag := names.NewStorageTag("shared-fs/12")
+	c.Assert(tag.StorageName(), gc.Equals, "shared-fs")
+	c.Assert(tag.Ordinal(), gc.Equals, 12)
+
+	tag = names.NewStorageTag("data/0")
+	c.Assert(tag.StorageName(), gc.Equals, "data")
+	c.Assert(tag.Ordinal(), gc.Equals, 0)
+}
+
+func (s *storageSuite) TestNewStorageTagFromParts(c *gc.C) {
+	tag, err := names.NewStorageTagFromParts("shared-fs", 3)
+	c.Assert(err, gc.IsNil)
+	c.Assert(tag, gc.Equals, names.NewStorageTag("shared-fs/3"))
+
+	_, err = names.NewStorageTagFromParts("data", -1)
+	c.Assert(err, gc.ErrorMatches, `-1 is not a valid storage instance ordinal`)
+	_, err = names.NewStorageTagFromParts("data/0", 1)
+	c.Assert(err, gc.ErrorMatches, `"data/0" is not a valid storage name`)
+	_, err = names.NewStorageTagFromParts("", 1)
+	c.Assert(err, gc.ErrorMatches, `"" is not a valid storage name`)
+}
+
+func (s *storageSuite) TestParseStorageOwnerTag(c *gc.C) {
+	owner, err := names.ParseStorageOwnerTag("unit-mysql-0")
+	c.Assert(err, gc.IsNil)
+	unit, ok := names.StorageOwnerUnit(owner)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(unit, gc.Equals, names.NewUnitTag("mysql/0"))
+	_, ok = names.StorageOwnerService(owner)
+	c.Assert(ok, gc.Equals, false)
+
+	owner, err = names.ParseStorageOwnerTag("service-mysql")
+	c.Assert(err, gc.IsNil)
+	service, ok := names.StorageOwnerService(owner)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(service, gc.Equals, names.NewServiceTag("mysql"))
+	_, ok = names.StorageOwnerUnit(owner)
+	c.Assert(ok, gc.Equals, false)
+
+	_, err = names.ParseStorageOwnerTag("machine-0")
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid storage owner tag`)
+	_, err = names.ParseStorageOwnerTag("unit-mysql")
+	c.Assert(err, gc.ErrorMatches, `"unit-mysql" is not a valid unit tag`)
+}
+
 func (s *serviceSuite) TestStorageName(c *gc.C) {
 	assertStorageNameValid(c, "shared-fs/0", "shared-fs")
 	assertStorageNameInvalid(c, "storage-shared-fs-0")
@@ -6,33 +6,89 @@ package names
 import (
 	"fmt"
 	"net"
+	"strings"
 )
 
 const SubnetTagKind = "subnet"
 
 // IsValidSubnet returns whether cidr is a valid subnet CIDR.
 func IsValidSubnet(cidr string) bool {
-	_, ipNet, err := net.ParseCIDR(cidr)
-	if err == nil && ipNet.String() == cidr {
-		return true
+	return ValidateSubnet(cidr) == nil
+}
+
+// ValidateSubnet returns an error describing why cidr is not a valid
+// subnet CIDR, or nil if it is valid. A valid subnet CIDR is an IPv4
+// or IPv6 network in CIDR notation, without a zone, with no host bits
+// set, and written in canonical form, e.g. "10.0.0.0/16" or
+// "2001:db8::/32".
+func ValidateSubnet(cidr string) error {
+	if !strings.Contains(cidr, "/") {
+		return fmt.Errorf("%q is not a valid subnet CIDR: missing prefix length", cidr)
+	}
+	if strings.Contains(cidr, "%") {
+		return fmt.Errorf("%q is not a valid subnet CIDR: zones are not allowed", cidr)
+	}
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid subnet CIDR: invalid address or prefix length", cidr)
 	}
-	return false
+	if !ip.Equal(ipNet.IP) {
+		return fmt.Errorf("%q is not a valid subnet CIDR: host bits are set, did you mean %q?", cidr, ipNet.String())
+	}
+	if ipNet.String() != cidr {
+		return fmt.Errorf("%q is not a valid subnet CIDR: not in canonical form, did you mean %q?", cidr, ipNet.String())
+	}
+	return nil
+}
+
+// IsValidSubnetID returns whether id is a valid numeric subnet ID.
+func IsValidSubnetID(id string) bool {
+	return validNumber.MatchString(id)
 }
 
+// SubnetTag represents a tag used to describe a subnet. Subnets are
+// identified either by a numeric ID or, for compatibility, by CIDR.
 type SubnetTag struct {
-	cidr string
+	id string
 }
 
-func (t SubnetTag) String() string { return t.Kind() + "-" + t.cidr }
-func (t SubnetTag) Kind() string   { return SubnetTagKind }
-func (t SubnetTag) Id() string     { return t.cidr }
+func (t SubnetTag) String() string      { return t.Kind() + "-" + t.id }
+func (t SubnetTag) Kind() string        { return SubnetTagKind }
+func (t SubnetTag) Id() string          { return t.id }
+func (t SubnetTag) Validate() error     { return validateTag(t) }
+func (t SubnetTag) PathSegment() string { return pathSegment(t) }
+
+// IsID returns whether the subnet is identified by numeric ID.
+func (t SubnetTag) IsID() bool {
+	return IsValidSubnetID(t.id)
+}
+
+// IsCIDR returns whether the subnet is identified by CIDR.
+func (t SubnetTag) IsCIDR() bool {
+	return t.id != "" && !t.IsID()
+}
+
+// CIDR returns the network of the subnet, and whether
+// the subnet is identified by CIDR.
+func (t SubnetTag) CIDR() (*net.IPNet, bool) {
+	if !t.IsCIDR() {
+		return nil, false
+	}
+	// The CIDR is validated on construction.
+	_, ipNet, err := net.ParseCIDR(t.id)
+	if err != nil {
+		return nil, false
+	}
+	return ipNet, true
+}
 
-// NewSubnetTag returns the tag for subnet with the given CIDR.
-func NewSubnetTag(cidr string) SubnetTag {
-	if !IsValidSubnet(cidr) {
-		panic(fmt.Sprintf("%s is not a valid subnet CIDR", cidr))
+// NewSubnetTag returns the tag for subnet with the given
+// numeric ID or CIDR.
+func NewSubnetTag(id string) SubnetTag {
+	if !IsValidSubnetID(id) && !IsValidSubnet(id) {
+		panic(fmt.Sprintf("%s is not a valid subnet CIDR", id))
 	}
-	return SubnetTag{cidr: cidr}
+	return SubnetTag{id: id}
 }
 
 // ParseSubnetTag parses a subnet tag string.
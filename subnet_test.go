@@ -28,6 +28,77 @@ func (s *subnetSuite) TestNewSubnetTag(c *gc.C) {
 	c.Assert(f, gc.PanicMatches, "foo is not a valid subnet CIDR")
 }
 
+func (s *subnetSuite) TestValidateSubnet(c *gc.C) {
+	for i, test := range []struct {
+		cidr string
+		err  string
+	}{
+		{cidr: "10.20.0.0/16"},
+		{cidr: "0.0.0.0/0"},
+		{cidr: "192.168.1.7/32"},
+		{cidr: "2001:db8::/32"},
+		{cidr: "::/0"},
+		{cidr: "fe80::/10"},
+		{cidr: "", err: `"" is not a valid subnet CIDR: missing prefix length`},
+		{cidr: "10.20.0.0", err: `"10.20.0.0" is not a valid subnet CIDR: missing prefix length`},
+		{cidr: "fe80::3%zone1/10", err: `"fe80::3%zone1/10" is not a valid subnet CIDR: zones are not allowed`},
+		{cidr: "10.20.0.0/33", err: `"10.20.0.0/33" is not a valid subnet CIDR: invalid address or prefix length`},
+		{cidr: "foo/8", err: `"foo/8" is not a valid subnet CIDR: invalid address or prefix length`},
+		{cidr: "10.20.30.40/16", err: `"10.20.30.40/16" is not a valid subnet CIDR: host bits are set, did you mean "10.20.0.0/16"\?`},
+		{cidr: "2001:db8::123/32", err: `"2001:db8::123/32" is not a valid subnet CIDR: host bits are set, did you mean "2001:db8::/32"\?`},
+		{cidr: "2001:DB8::/32", err: `"2001:DB8::/32" is not a valid subnet CIDR: not in canonical form, did you mean "2001:db8::/32"\?`},
+		{cidr: "2001:db8:0::/48", err: `"2001:db8:0::/48" is not a valid subnet CIDR: not in canonical form, did you mean "2001:db8::/48"\?`},
+	} {
+		c.Logf("test %d: %q", i, test.cidr)
+		err := names.ValidateSubnet(test.cidr)
+		c.Check(names.IsValidSubnet(test.cidr), gc.Equals, test.err == "")
+		if test.err == "" {
+			c.Check(err, gc.IsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *subnetSuite) TestSubnetTagForms(c *gc.C) {
+	tag := names.NewSubnetTag("42")
+	c.Check(tag.IsID(), gc.Equals, true)
+	c.Check(tag.IsCIDR(), gc.Equals, false)
+	c.Check(tag.String(), gc.Equals, "subnet-42")
+
+	tag = names.NewSubnetTag("10.20.0.0/16")
+	c.Check(tag.IsID(), gc.Equals, false)
+	c.Check(tag.IsCIDR(), gc.Equals, true)
+
+	c.Check(names.SubnetTag{}.IsID(), gc.Equals, false)
+	c.Check(names.SubnetTag{}.IsCIDR(), gc.Equals, false)
+}
+
+func (s *subnetSuite) TestSubnetTagCIDR(c *gc.C) {
+	ipNet, ok := names.NewSubnetTag("10.20.0.0/16").CIDR()
+	c.Assert(ok, gc.Equals, true)
+	c.Check(ipNet.IP.String(), gc.Equals, "10.20.0.0")
+	c.Check(ipNet.Mask.String(), gc.Equals, "ffff0000")
+	c.Check(ipNet.String(), gc.Equals, "10.20.0.0/16")
+
+	ipNet, ok = names.NewSubnetTag("2001:db8::/32").CIDR()
+	c.Assert(ok, gc.Equals, true)
+	c.Check(ipNet.String(), gc.Equals, "2001:db8::/32")
+
+	ipNet, ok = names.NewSubnetTag("42").CIDR()
+	c.Check(ok, gc.Equals, false)
+	c.Check(ipNet, gc.IsNil)
+}
+
+func (s *subnetSuite) TestIsValidSubnetID(c *gc.C) {
+	c.Check(names.IsValidSubnetID("0"), gc.Equals, true)
+	c.Check(names.IsValidSubnetID("42"), gc.Equals, true)
+	c.Check(names.IsValidSubnetID("042"), gc.Equals, false)
+	c.Check(names.IsValidSubnetID("-1"), gc.Equals, false)
+	c.Check(names.IsValidSubnetID(""), gc.Equals, false)
+	c.Check(names.IsValidSubnetID("10.20.0.0/16"), gc.Equals, false)
+}
+
 var parseSubnetTagTests = []struct {
 	tag      string
 	expected names.Tag
@@ -41,6 +112,12 @@ var parseSubnetTagTests = []struct {
 }, {
 	tag:      "subnet-2001:db8::/32",
 	expected: names.NewSubnetTag("2001:db8::/32"),
+}, {
+	tag:      "subnet-42",
+	expected: names.NewSubnetTag("42"),
+}, {
+	tag: "subnet-042",
+	err: names.InvalidTagError("subnet-042", names.SubnetTagKind),
 }, {
 	tag: "subnet-fe80::3%zone1/10",
 	err: names.InvalidTagError("subnet-fe80::3%zone1/10", names.SubnetTagKind),
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// kindPlurals holds the plural forms of kinds
+// that are not formed by appending "s".
+var kindPlurals = map[string]string{
+	IPAddressTagKind: "ipaddresses",
+}
+
+// Summarize returns a compact human-readable summary of the given
+// tags, suitable for confirmation prompts and audit logs, such as
+// "3 units of mysql, machines 0-2, model 1a2b3c".
+//
+// Tags are grouped in the order in which their groups first appear.
+// Units are counted by service, machines are shown as ranges of
+// machine numbers and containers are counted by host machine. Model
+// and controller UUIDs are abbreviated to the 6 characters of
+// ModelTag.ShortId, as in hostnames. Nil and duplicate tags are
+// ignored.
+func Summarize(tags []Tag) string {
+	var s summary
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if isNilTag(tag) || seen[tag.String()] {
+			continue
+		}
+		seen[tag.String()] = true
+		switch t := tag.(type) {
+		case UnitTag:
+			s.add("unit "+t.Service().Id(), tag)
+		case MachineTag:
+			if parent, ok := t.Parent(); ok {
+				s.add("container "+parent.Id(), tag)
+			} else {
+				s.add(MachineTagKind, tag)
+			}
+		default:
+			s.add(tag.Kind(), tag)
+		}
+	}
+	parts := make([]string, len(s.groups))
+	for i, g := range s.groups {
+		parts[i] = g.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// summary holds tags grouped by key, in order of first appearance.
+type summary struct {
+	groups []*summaryGroup
+	byKey  map[string]*summaryGroup
+}
+
+func (s *summary) add(key string, tag Tag) {
+	g := s.byKey[key]
+	if g == nil {
+		if s.byKey == nil {
+			s.byKey = make(map[string]*summaryGroup)
+		}
+		g = &summaryGroup{}
+		s.byKey[key] = g
+		s.groups = append(s.groups, g)
+	}
+	g.tags = append(g.tags, tag)
+}
+
+// summaryGroup holds tags of the same kind that are summarized
+// together.
+type summaryGroup struct {
+	tags []Tag
+}
+
+func (g *summaryGroup) String() string {
+	if len(g.tags) == 1 {
+		tag := g.tags[0]
+		switch tag.(type) {
+		case ModelTag, EnvironTag, ControllerTag:
+			return tag.Kind() + " " + summaryShortId(tag)
+		}
+		return ReadableString(tag)
+	}
+	n := len(g.tags)
+	switch tag := g.tags[0].(type) {
+	case UnitTag:
+		return fmt.Sprintf("%d units of %s", n, tag.Service().Id())
+	case MachineTag:
+		if parent, ok := tag.Parent(); ok {
+			return fmt.Sprintf("%d containers on %s", n, ReadableString(parent))
+		}
+		return "machines " + machineRanges(g.tags)
+	}
+	ids := make([]string, n)
+	for i, tag := range g.tags {
+		switch tag.(type) {
+		case ModelTag, EnvironTag, ControllerTag:
+			ids[i] = summaryShortId(tag)
+		default:
+			ids[i] = displayId(tag)
+		}
+	}
+	return fmt.Sprintf("%d %s (%s)", n, kindPlural(g.tags[0].Kind()), strings.Join(ids, ", "))
+}
+
+// summaryShortId returns the abbreviated UUID of the given model,
+// environment or controller tag, as returned by ModelTag.ShortId.
+func summaryShortId(tag Tag) string {
+	id := tag.Id()
+	if len(id) > shortModelIdLen {
+		id = id[:shortModelIdLen]
+	}
+	return id
+}
+
+// machineRanges returns the numbers of the given top level machine
+// tags in ascending order, with consecutive numbers collapsed into
+// ranges, such as "0-2,5".
+func machineRanges(tags []Tag) string {
+	nums := make([]uint64, len(tags))
+	for i, tag := range tags {
+		// Machine ids are validated on construction,
+		// so the conversion cannot fail for valid tags.
+		nums[i], _ = strconv.ParseUint(tag.Id(), 10, 64)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	var ranges []string
+	for i := 0; i < len(nums); {
+		j := i
+		for j+1 < len(nums) && nums[j+1] == nums[j]+1 {
+			j++
+		}
+		r := strconv.FormatUint(nums[i], 10)
+		if j > i {
+			r += "-" + strconv.FormatUint(nums[j], 10)
+		}
+		ranges = append(ranges, r)
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
+
+// kindPlural returns the plural form of the given kind.
+func kindPlural(kind string) string {
+	if plural, ok := kindPlurals[kind]; ok {
+		return plural
+	}
+	return kind + "s"
+}
@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type summarySuite struct{}
+
+var _ = gc.Suite(&summarySuite{})
+
+const summaryUUID = "1a2b3c4d-58cc-4372-a567-0e02b2c3d479"
+
+var summaryTests = []struct {
+	about  string
+	tags   []names.Tag
+	expect string
+}{{
+	about:  "no tags",
+	expect: "",
+}, {
+	about:  "nil tags are ignored",
+	tags:   []names.Tag{nil, names.NewMachineTag("3"), nil},
+	expect: "machine 3",
+}, {
+	about: "units are counted by service",
+	tags: []names.Tag{
+		names.NewUnitTag("mysql/0"),
+		names.NewUnitTag("wordpress/1"),
+		names.NewUnitTag("mysql/1"),
+		names.NewUnitTag("mysql/2"),
+	},
+	expect: "3 units of mysql, unit 1 of wordpress",
+}, {
+	about: "machines are shown as ranges",
+	tags: []names.Tag{
+		names.NewMachineTag("2"),
+		names.NewMachineTag("0"),
+		names.NewMachineTag("10"),
+		names.NewMachineTag("1"),
+		names.NewMachineTag("5"),
+		names.NewMachineTag("4"),
+	},
+	expect: "machines 0-2,4-5,10",
+}, {
+	about: "containers are counted by host",
+	tags: []names.Tag{
+		names.NewMachineTag("0/lxd/0"),
+		names.NewMachineTag("0/lxd/1"),
+		names.NewMachineTag("1/kvm/0"),
+		names.NewMachineTag("0"),
+	},
+	expect: "2 containers on machine 0, container 0 (kvm) on machine 1, machine 0",
+}, {
+	about: "duplicates are ignored",
+	tags: []names.Tag{
+		names.NewUnitTag("mysql/0"),
+		names.NewUnitTag("mysql/0"),
+		names.NewMachineTag("1"),
+		names.NewMachineTag("1"),
+	},
+	expect: "unit 0 of mysql, machine 1",
+}, {
+	about: "models are abbreviated",
+	tags: []names.Tag{
+		names.NewUnitTag("mysql/0"),
+		names.NewUnitTag("mysql/1"),
+		names.NewUnitTag("mysql/2"),
+		names.NewMachineTag("0"),
+		names.NewMachineTag("1"),
+		names.NewMachineTag("2"),
+		names.NewModelTag(summaryUUID),
+	},
+	expect: "3 units of mysql, machines 0-2, model 1a2b3c",
+}, {
+	about: "other kinds are listed",
+	tags: []names.Tag{
+		names.NewServiceTag("mysql"),
+		names.NewServiceTag("wordpress"),
+		names.NewIPAddressTag(summaryUUID),
+		names.NewIPAddressTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		names.NewModelTag(summaryUUID),
+		names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		names.NewRelationTag("wordpress:db mysql:server"),
+	},
+	expect: "2 services (mysql, wordpress), " +
+		"2 ipaddresses (1a2b3c4d-58cc-4372-a567-0e02b2c3d479, f47ac10b-58cc-4372-a567-0e02b2c3d479), " +
+		"2 models (1a2b3c, f47ac1), " +
+		"relation wordpress:db mysql:server",
+}, {
+	about: "controllers are abbreviated",
+	tags: []names.Tag{
+		names.NewControllerTag(summaryUUID),
+		names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	},
+	expect: "controller 1a2b3c, model f47ac1",
+}, {
+	about: "groups of controllers are abbreviated",
+	tags: []names.Tag{
+		names.NewControllerTag(summaryUUID),
+		names.NewControllerTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	},
+	expect: "2 controllers (1a2b3c, f47ac1)",
+}}
+
+func (s *summarySuite) TestSummarize(c *gc.C) {
+	for i, test := range summaryTests {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(names.Summarize(test.tags), gc.Equals, test.expect)
+	}
+}
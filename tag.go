@@ -5,9 +5,8 @@ package names
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
-
-	"github.com/juju/utils"
 )
 
 // A Tag tags things that are taggable. Its purpose is to uniquely
@@ -51,145 +50,284 @@ func tagString(tag Tag) string {
 // TagKind returns one of the *TagKind constants for the given tag, or
 // an error if none matches.
 func TagKind(tag string) (string, error) {
+	kind, ok := tagKind(tag)
+	if !ok {
+		return "", fmt.Errorf("%q is not a valid tag", tag)
+	}
+	return kind, nil
+}
+
+// tagKind is like TagKind, but reports failure without
+// allocating an error.
+func tagKind(tag string) (string, bool) {
 	i := strings.Index(tag, "-")
 	if i <= 0 || !validKinds(tag[:i]) {
-		return "", fmt.Errorf("%q is not a valid tag", tag)
+		return "", false
 	}
-	return tag[:i], nil
+	return tag[:i], true
 }
 
 func validKinds(kind string) bool {
-	switch kind {
-	case UnitTagKind, MachineTagKind, ServiceTagKind, EnvironTagKind, UserTagKind,
-		RelationTagKind, ActionTagKind, VolumeTagKind, CharmTagKind, StorageTagKind,
-		FilesystemTagKind, IPAddressTagKind, SpaceTagKind, SubnetTagKind,
-		PayloadTagKind, ModelTagKind:
-		return true
-	}
-	return false
+	_, ok := kindHandlers[kind]
+	return ok
 }
 
-func splitTag(tag string) (string, string, error) {
-	kind, err := TagKind(tag)
-	if err != nil {
-		return "", "", err
+func splitTag(tag string) (string, string, bool) {
+	kind, ok := tagKind(tag)
+	if !ok {
+		return "", "", false
 	}
-	return kind, tag[len(kind)+1:], nil
+	return kind, tag[len(kind)+1:], true
 }
 
 // ParseTag parses a string representation into a Tag.
 func ParseTag(tag string) (Tag, error) {
-	kind, id, err := splitTag(tag)
-	if err != nil {
+	kind, id, ok := splitTag(tag)
+	if !ok {
 		return nil, invalidTagError(tag, "")
 	}
-	switch kind {
-	case UnitTagKind:
-		id = unitTagSuffixToId(id)
-		if !IsValidUnit(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewUnitTag(id), nil
-	case MachineTagKind:
-		id = machineTagSuffixToId(id)
-		if !IsValidMachine(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewMachineTag(id), nil
-	case ServiceTagKind:
-		if !IsValidService(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewServiceTag(id), nil
-	case UserTagKind:
-		if !IsValidUser(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewUserTag(id), nil
-	case EnvironTagKind:
-		if !IsValidEnvironment(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewEnvironTag(id), nil
-	case ModelTagKind:
-		if !IsValidModel(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewModelTag(id), nil
-	case RelationTagKind:
-		id = relationTagSuffixToKey(id)
-		if !IsValidRelation(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewRelationTag(id), nil
-	case ActionTagKind:
-		if !IsValidAction(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewActionTag(id), nil
-	case VolumeTagKind:
-		id = volumeTagSuffixToId(id)
-		if !IsValidVolume(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewVolumeTag(id), nil
-	case CharmTagKind:
-		if !IsValidCharm(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewCharmTag(id), nil
-	case StorageTagKind:
-		id = storageTagSuffixToId(id)
-		if !IsValidStorage(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewStorageTag(id), nil
-	case FilesystemTagKind:
-		id = filesystemTagSuffixToId(id)
-		if !IsValidFilesystem(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewFilesystemTag(id), nil
-	case IPAddressTagKind:
-		uuid, err := utils.UUIDFromString(id)
-		if err != nil {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewIPAddressTag(uuid.String()), nil
-	case SubnetTagKind:
-		if !IsValidSubnet(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewSubnetTag(id), nil
-	case SpaceTagKind:
-		if !IsValidSpace(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewSpaceTag(id), nil
-	case PayloadTagKind:
-		if !isValidPayload(id) {
-			return nil, invalidTagError(tag, kind)
-		}
-		return NewPayloadTag(id), nil
-	default:
-		return nil, invalidTagError(tag, "")
+	t, ok := tagFromId(kind, tagSuffixToId(kind, id))
+	if !ok {
+		return nil, invalidTagError(tag, kind)
+	}
+	return t, nil
+}
+
+// IsValidTag returns whether tag is a valid tag string, as accepted
+// by ParseTag. It is cheaper than calling ParseTag, as it allocates
+// neither a tag nor an error.
+func IsValidTag(tag string) bool {
+	kind, suffix, ok := splitTag(tag)
+	if !ok {
+		return false
 	}
+	return kindHandlers[kind].isValidId(tagSuffixToId(kind, suffix))
 }
 
-func invalidTagError(tag, kind string) error {
-	if kind != "" {
-		return fmt.Errorf("%q is not a valid %s tag", tag, kind)
+// idFromTagString returns the id of the tag of the given kind
+// represented by s, without constructing the tag.
+func idFromTagString(kind, s string) (string, error) {
+	suffix, err := StripKind(kind, s)
+	if err != nil {
+		return "", err
+	}
+	id := tagSuffixToId(kind, suffix)
+	if !kindHandlers[kind].isValidId(id) {
+		return "", invalidTagError(s, kind)
+	}
+	return id, nil
+}
+
+// tagSuffixToId converts the part of a tag string following
+// its kind into the id of the tag.
+func tagSuffixToId(kind, suffix string) string {
+	if h := kindHandlers[kind]; h.suffixToId != nil {
+		return h.suffixToId(suffix)
+	}
+	return suffix
+}
+
+// tagFromId returns the tag of the given kind with the given id,
+// and whether the id is valid for that kind.
+func tagFromId(kind, id string) (Tag, bool) {
+	h, ok := kindHandlers[kind]
+	if !ok || !h.isValidId(id) {
+		return nil, false
 	}
-	return fmt.Errorf("%q is not a valid tag", tag)
+	return h.newTag(id), true
+}
+
+// kindHandler holds the functions used to parse tags of one kind.
+type kindHandler struct {
+	// suffixToId converts the part of a tag string following the
+	// kind into the id of the tag. If it is nil, they are the same.
+	suffixToId func(suffix string) string
+
+	// isValidId returns whether the given id is valid.
+	isValidId func(id string) bool
+
+	// newTag returns the tag with the given valid id.
+	newTag func(id string) Tag
+
+	// category holds the category of the kind.
+	category Category
+}
+
+// kindHandlers holds the handler for each valid tag kind.
+var kindHandlers = map[string]kindHandler{
+	UnitTagKind: {
+		suffixToId: unitTagSuffixToId,
+		isValidId:  IsValidUnit,
+		newTag:     func(id string) Tag { return NewUnitTag(id) },
+		category:   AgentCategory,
+	},
+	MachineTagKind: {
+		suffixToId: machineTagSuffixToId,
+		isValidId:  IsValidMachine,
+		newTag:     func(id string) Tag { return NewMachineTag(id) },
+		category:   AgentCategory,
+	},
+	ServiceTagKind: {
+		isValidId: IsValidService,
+		newTag:    func(id string) Tag { return NewServiceTag(id) },
+		category:  WorkloadCategory,
+	},
+	UserTagKind: {
+		isValidId: IsValidUser,
+		newTag:    func(id string) Tag { return NewUserTag(id) },
+		category:  IdentityCategory,
+	},
+	EnvironTagKind: {
+		suffixToId: lowerUUID,
+		isValidId:  IsValidEnvironment,
+		newTag:     func(id string) Tag { return NewEnvironTag(id) },
+		category:   ModelCategory,
+	},
+	ControllerTagKind: {
+		suffixToId: lowerUUID,
+		isValidId:  IsValidController,
+		newTag:     func(id string) Tag { return NewControllerTag(id) },
+		category:   ModelCategory,
+	},
+	ModelTagKind: {
+		suffixToId: lowerUUID,
+		isValidId:  IsValidModel,
+		newTag:     func(id string) Tag { return NewModelTag(id) },
+		category:   ModelCategory,
+	},
+	RelationTagKind: {
+		suffixToId: relationTagSuffixToKey,
+		isValidId:  IsValidRelation,
+		newTag:     func(id string) Tag { return NewRelationTag(id) },
+		category:   WorkloadCategory,
+	},
+	ActionTagKind: {
+		isValidId: IsValidAction,
+		newTag:    func(id string) Tag { return NewActionTag(id) },
+		category:  WorkloadCategory,
+	},
+	VolumeTagKind: {
+		suffixToId: volumeTagSuffixToId,
+		isValidId:  IsValidVolume,
+		newTag:     func(id string) Tag { return NewVolumeTag(id) },
+		category:   StorageCategory,
+	},
+	CharmTagKind: {
+		isValidId: IsValidCharm,
+		newTag:    func(id string) Tag { return NewCharmTag(id) },
+		category:  WorkloadCategory,
+	},
+	StorageTagKind: {
+		suffixToId: storageTagSuffixToId,
+		isValidId:  IsValidStorage,
+		newTag:     func(id string) Tag { return NewStorageTag(id) },
+		category:   StorageCategory,
+	},
+	FilesystemTagKind: {
+		suffixToId: filesystemTagSuffixToId,
+		isValidId:  IsValidFilesystem,
+		newTag:     func(id string) Tag { return NewFilesystemTag(id) },
+		category:   StorageCategory,
+	},
+	VolumeAttachmentTagKind: {
+		suffixToId: volumeAttachmentTagSuffixToId,
+		isValidId:  IsValidVolumeAttachment,
+		newTag: func(id string) Tag {
+			tag, _ := tagFromVolumeAttachmentId(id)
+			return tag
+		},
+		category: StorageCategory,
+	},
+	FilesystemAttachmentTagKind: {
+		suffixToId: filesystemAttachmentTagSuffixToId,
+		isValidId:  IsValidFilesystemAttachment,
+		newTag: func(id string) Tag {
+			tag, _ := tagFromFilesystemAttachmentId(id)
+			return tag
+		},
+		category: StorageCategory,
+	},
+	IPAddressTagKind: {
+		suffixToId: lowerUUID,
+		isValidId: func(id string) bool {
+			return isIPAddressLiteral(id) || IsValidUUID(id)
+		},
+		newTag:   func(id string) Tag { return NewIPAddressTag(id) },
+		category: NetworkingCategory,
+	},
+	SubnetTagKind: {
+		isValidId: func(id string) bool {
+			return IsValidSubnetID(id) || IsValidSubnet(id)
+		},
+		newTag:   func(id string) Tag { return NewSubnetTag(id) },
+		category: NetworkingCategory,
+	},
+	SpaceTagKind: {
+		isValidId: IsValidSpace,
+		newTag:    func(id string) Tag { return NewSpaceTag(id) },
+		category:  NetworkingCategory,
+	},
+	PayloadTagKind: {
+		isValidId: isValidPayload,
+		newTag:    func(id string) Tag { return NewPayloadTag(id) },
+		category:  WorkloadCategory,
+	},
+}
+
+// Equal returns whether a and b are the same tag: that is, whether
+// they have the same kind and string form. Unlike comparing tags with
+// ==, it treats nil pointers to tags as nil tags rather than calling
+// their methods, it considers a pointer to a tag equal to the tag, and
+// it does not panic on tags of types that cannot be compared.
+func Equal(a, b Tag) bool {
+	aNil, bNil := isNilTag(a), isNilTag(b)
+	if aNil || bNil {
+		return aNil && bNil
+	}
+	return a.Kind() == b.Kind() && a.String() == b.String()
+}
+
+// isNilTag returns whether tag is nil, or holds a nil value.
+func isNilTag(tag Tag) bool {
+	if tag == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(tag); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
 }
 
 // ReadableString returns a human-readable string from the tag passed in.
-// It currently supports unit and machine tags. Support for additional types
-// can be added in as needed.
+// Machines and units are phrased without exposing their id syntax,
+// as in "container 3 (lxd) on machine 0" or "unit 0 of mysql"; other
+// tags are rendered as their kind followed by their id.
+// Use LocalizedReadableString to render it in another language.
+// Sensitive parts of the id are masked if redaction is enabled
+// (see SetRedactSensitiveTags).
 func ReadableString(tag Tag) string {
-	if tag == nil {
+	return LocalizedReadableString(nil, tag)
+}
+
+// ShortString returns an abbreviated form of the tag for display in
+// log lines and tables. Model and controller tags are abbreviated to
+// the first 8 characters of their UUID; other tags are shown by their id.
+// The result is for display only: it is not guaranteed to be unique
+// and cannot be parsed back into a tag.
+func ShortString(tag Tag) string {
+	switch tag := tag.(type) {
+	case nil:
 		return ""
+	case ModelTag, EnvironTag, ControllerTag:
+		id := tag.Id()
+		if len(id) > shortUUIDLen {
+			id = id[:shortUUIDLen]
+		}
+		return id
 	}
-
-	return tag.Kind() + " " + tag.Id()
+	return tag.Id()
 }
+
+// shortUUIDLen holds the number of UUID characters shown by ShortString.
+const shortUUIDLen = 8
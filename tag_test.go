@@ -4,6 +4,11 @@
 package names_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/names"
@@ -23,6 +28,7 @@ var tagKindTests = []struct {
 	{tag: "service-foo", kind: names.ServiceTagKind},
 	{tag: "environment-42", kind: names.EnvironTagKind},
 	{tag: "model-42", kind: names.ModelTagKind},
+	{tag: "controller-42", kind: names.ControllerTagKind},
 	{tag: "user-admin", kind: names.UserTagKind},
 	{tag: "relation-service1.rel1#other-svc.other-rel2", kind: names.RelationTagKind},
 	{tag: "relation-service.peerRelation", kind: names.RelationTagKind},
@@ -273,6 +279,39 @@ func (*tagSuite) TestParseTag(c *gc.C) {
 	}
 }
 
+func (*tagSuite) TestIsValidTag(c *gc.C) {
+	for i, test := range parseTagTests {
+		c.Logf("test %d: %q", i, test.tag)
+		_, err := names.ParseTag(test.tag)
+		c.Check(names.IsValidTag(test.tag), gc.Equals, err == nil)
+	}
+}
+
+func (*tagSuite) TestIsValidTagAllocs(c *gc.C) {
+	// Tags whose ids are the same as their suffixes need no
+	// allocations, valid or not, beyond those of the validator
+	// for their kind. Others allocate their id too.
+	for _, test := range []struct {
+		tag    string
+		allocs float64
+	}{
+		{"service-mysql", 0},
+		{"user-bob@local", 2}, // The user validator allocates.
+		{"model-f47ac10b-58cc-4372-a567-0e02b2c3d479", 0},
+		{"service-1mysql", 0},
+		{"foo-bar", 0},
+		{"", 0},
+		{"unit-mysql-0", 1},
+		{"machine-0-lxc-1", 1},
+		{"unit-mysql", 1},
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			names.IsValidTag(test.tag)
+		})
+		c.Check(allocs <= test.allocs, jc.IsTrue, gc.Commentf("%q: %v allocations", test.tag, allocs))
+	}
+}
+
 func (*tagSuite) TestReadableString(c *gc.C) {
 	var readableStringTests = []struct {
 		tag    names.Tag
@@ -283,9 +322,18 @@ func (*tagSuite) TestReadableString(c *gc.C) {
 	}, {
 		tag:    names.NewMachineTag("0"),
 		result: "machine 0",
+	}, {
+		tag:    names.NewMachineTag("0/lxd/3"),
+		result: "container 3 (lxd) on machine 0",
+	}, {
+		tag:    names.NewMachineTag("1/lxc/0/kvm/2"),
+		result: "container 2 (kvm) on container 0 (lxc) on machine 1",
 	}, {
 		tag:    names.NewUnitTag("wordpress/2"),
-		result: "unit wordpress/2",
+		result: "unit 2 of wordpress",
+	}, {
+		tag:    names.NewServiceTag("wordpress"),
+		result: "service wordpress",
 	}}
 
 	for i, test := range readableStringTests {
@@ -294,3 +342,73 @@ func (*tagSuite) TestReadableString(c *gc.C) {
 		c.Assert(resultStr, gc.Equals, test.result)
 	}
 }
+
+func (*tagSuite) TestShortString(c *gc.C) {
+	for i, test := range []struct {
+		tag    names.Tag
+		result string
+	}{{
+		tag:    nil,
+		result: "",
+	}, {
+		tag:    names.NewModelTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		result: "f47ac10b",
+	}, {
+		tag:    names.NewEnvironTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		result: "f47ac10b",
+	}, {
+		tag:    names.NewMachineTag("0/lxc/1"),
+		result: "0/lxc/1",
+	}, {
+		tag:    names.NewUnitTag("wordpress/2"),
+		result: "wordpress/2",
+	}} {
+		c.Logf("test %d: expected result %q", i, test.result)
+		c.Check(names.ShortString(test.tag), gc.Equals, test.result)
+	}
+}
+
+// BenchmarkEncodeEntities measures the cost of encoding a
+// large list of entities, as returned by many API calls.
+func BenchmarkEncodeEntities(b *testing.B) {
+	type entity struct {
+		Tag string `json:"tag"`
+	}
+	var tags []names.Tag
+	for i := 0; i < 1000; i++ {
+		tags = append(tags,
+			names.NewMachineTag(fmt.Sprint(i)),
+			names.NewUnitTag(fmt.Sprintf("mysql/%d", i)),
+		)
+	}
+	entities := make([]entity, len(tags))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, tag := range tags {
+			entities[j].Tag = tag.String()
+		}
+		if _, err := json.Marshal(entities); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIsValidTag(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		names.IsValidTag("unit-mysql-0")
+	}
+}
+
+func BenchmarkParseTagCommonKind(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		names.ParseTag("unit-mysql-0")
+	}
+}
+
+func BenchmarkParseTagUncommonKind(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		names.ParseTag("payload-spam")
+	}
+}
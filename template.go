@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// TemplateFuncs returns functions for formatting tags in templates.
+// Each function accepts either a Tag or a tag string:
+//
+//	tag       returns the Tag parsed from a tag string
+//	tagId     returns the id of a tag
+//	tagKind   returns the kind of a tag
+//	readable  returns the human-readable form of a tag (see ReadableString)
+//
+// For example: {{readable "unit-mysql-0"}} renders "unit 0 of mysql".
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"tag": templateTag,
+		"tagId": func(v interface{}) (string, error) {
+			tag, err := templateTag(v)
+			if err != nil {
+				return "", err
+			}
+			return tag.Id(), nil
+		},
+		"tagKind": func(v interface{}) (string, error) {
+			tag, err := templateTag(v)
+			if err != nil {
+				return "", err
+			}
+			return tag.Kind(), nil
+		},
+		"readable": func(v interface{}) (string, error) {
+			tag, err := templateTag(v)
+			if err != nil {
+				return "", err
+			}
+			return ReadableString(tag), nil
+		},
+	}
+}
+
+func templateTag(v interface{}) (Tag, error) {
+	switch v := v.(type) {
+	case Tag:
+		return v, nil
+	case string:
+		return ParseTag(v)
+	}
+	return nil, fmt.Errorf("cannot use %T as a tag", v)
+}
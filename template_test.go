@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"bytes"
+	"text/template"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type templateSuite struct{}
+
+var _ = gc.Suite(&templateSuite{})
+
+func (s *templateSuite) TestTemplateFuncs(c *gc.C) {
+	for i, test := range []struct {
+		text   string
+		data   interface{}
+		expect string
+		err    string
+	}{{
+		text:   `{{tagKind "unit-mysql-0"}} {{tagId "unit-mysql-0"}}`,
+		expect: "unit mysql/0",
+	}, {
+		text:   `{{readable .}}`,
+		data:   names.NewMachineTag("0/lxc/1"),
+		expect: "container 1 (lxc) on machine 0",
+	}, {
+		text:   `{{(tag "service-mysql").Id}}`,
+		expect: "mysql",
+	}, {
+		text:   `{{tagId . | printf "%q"}}`,
+		data:   "user-bob@local",
+		expect: `"bob@local"`,
+	}, {
+		text: `{{tagId "foo"}}`,
+		err:  `.*"foo" is not a valid tag`,
+	}, {
+		text: `{{tagKind 42}}`,
+		err:  `.*cannot use int as a tag`,
+	}} {
+		c.Logf("test %d: %s", i, test.text)
+		t := template.Must(template.New("").Funcs(names.TemplateFuncs()).Parse(test.text))
+		var buf bytes.Buffer
+		err := t.Execute(&buf, test.data)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(buf.String(), gc.Equals, test.expect)
+	}
+}
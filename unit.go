@@ -5,21 +5,75 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
 const UnitTagKind = "unit"
 
-var validUnit = regexp.MustCompile("^(" + ServiceSnippet + ")/" + NumberSnippet + "$")
-
+// UnitTag represents a tag used to describe a unit. It holds its
+// complete tag string, so that String does not need to build it.
 type UnitTag struct {
-	name string
+	tag string
+}
+
+func (t UnitTag) String() string {
+	if t.tag == "" {
+		return t.Kind() + "-"
+	}
+	return t.tag
+}
+
+func (t UnitTag) Kind() string        { return UnitTagKind }
+func (t UnitTag) Id() string          { return unitTagSuffixToId(t.suffix()) }
+func (t UnitTag) Validate() error     { return validateTag(t) }
+func (t UnitTag) PathSegment() string { return pathSegment(t) }
+
+// suffix returns the part of the tag string following the kind.
+func (t UnitTag) suffix() string {
+	if t.tag == "" {
+		return ""
+	}
+	return t.tag[len(UnitTagKind)+1:]
+}
+
+// Number returns the unit's number within its service,
+// e.g. 2 for unit wordpress/2.
+func (t UnitTag) Number() int {
+	// The name is validated on construction, so
+	// the conversion cannot fail for valid tags.
+	name := t.suffix()
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+	return n
+}
+
+// Service returns the tag of the service
+// the unit belongs to.
+func (t UnitTag) Service() ServiceTag {
+	name := t.suffix()
+	i := strings.LastIndex(name, "-")
+	if i == -1 {
+		return ServiceTag{}
+	}
+	return NewServiceTag(name[:i])
+}
+
+// SortKey returns a key by which unit tags may be ordered
+// naturally: by service name, then numerically by unit number, so
+// that mysql/9 comes before mysql/10. The key is only meaningful
+// for comparison with other keys.
+func (t UnitTag) SortKey() string {
+	// The separator sorts before any character valid in
+	// service names, so that "mysql" precedes "mysql-router".
+	return t.Service().Id() + " " + sortKeyNumber(strconv.Itoa(t.Number()))
 }
 
-func (t UnitTag) String() string { return t.Kind() + "-" + t.name }
-func (t UnitTag) Kind() string   { return UnitTagKind }
-func (t UnitTag) Id() string     { return unitTagSuffixToId(t.name) }
+// PathKey returns a form of the unit name that is safe for use as a
+// file name, DNS label or key segment, e.g. "mysql-0" for unit
+// mysql/0. See UnitTagFromPathKey for the inverse.
+func (t UnitTag) PathKey() string {
+	return t.suffix()
+}
 
 // NewUnitTag returns the tag for the unit with the given name.
 // It will panic if the given unit name is not valid.
@@ -31,6 +85,18 @@ func NewUnitTag(unitName string) UnitTag {
 	return tag
 }
 
+// NewUnitTagFromParts returns the tag for unit number n
+// of the service with the given name.
+func NewUnitTagFromParts(serviceName string, n int) (UnitTag, error) {
+	if !IsValidService(serviceName) {
+		return UnitTag{}, fmt.Errorf("%q is not a valid service name", serviceName)
+	}
+	if n < 0 {
+		return UnitTag{}, fmt.Errorf("%d is not a valid unit number", n)
+	}
+	return UnitTag{tag: UnitTagKind + "-" + serviceName + "-" + strconv.Itoa(n)}, nil
+}
+
 // ParseUnitTag parses a unit tag string.
 func ParseUnitTag(unitTag string) (UnitTag, error) {
 	tag, err := ParseTag(unitTag)
@@ -44,19 +110,47 @@ func ParseUnitTag(unitTag string) (UnitTag, error) {
 	return ut, nil
 }
 
+// UnitIdFromTagString returns the unit name identified by the given
+// unit tag string, such as "mysql/0" for "unit-mysql-0". It is
+// cheaper than parsing the tag and calling its Id method.
+func UnitIdFromTagString(s string) (string, error) {
+	return idFromTagString(UnitTagKind, s)
+}
+
+// UnitTagFromPathKey returns the tag of the unit
+// with the given path key (see UnitTag.PathKey).
+func UnitTagFromPathKey(key string) (UnitTag, error) {
+	tag, ok := tagFromUnitName(unitTagSuffixToId(key))
+	if !ok || strings.Contains(key, "/") {
+		return UnitTag{}, fmt.Errorf("%q is not a valid unit path key", key)
+	}
+	return tag, nil
+}
+
 // IsValidUnit returns whether name is a valid unit name.
 func IsValidUnit(name string) bool {
-	return validUnit.MatchString(name)
+	return scanUnitName(name)
 }
 
 // UnitService returns the name of the service that the unit is
 // associated with. It returns an error if unitName is not a valid unit name.
 func UnitService(unitName string) (string, error) {
-	s := validUnit.FindStringSubmatch(unitName)
-	if s == nil {
+	if !IsValidUnit(unitName) {
 		return "", fmt.Errorf("%q is not a valid unit name", unitName)
 	}
-	return s[1], nil
+	return unitName[:strings.LastIndex(unitName, "/")], nil
+}
+
+// UnitsOf returns the tags of the units of the given service
+// found in tags, in the order they appear there.
+func UnitsOf(service ServiceTag, tags []Tag) []UnitTag {
+	var units []UnitTag
+	for _, tag := range tags {
+		if unit, ok := tag.(UnitTag); ok && unit.Service() == service {
+			units = append(units, unit)
+		}
+	}
+	return units
 }
 
 func tagFromUnitName(unitName string) (UnitTag, bool) {
@@ -66,7 +160,7 @@ func tagFromUnitName(unitName string) (UnitTag, bool) {
 		return UnitTag{}, false
 	}
 	unitName = unitName[:i] + "-" + unitName[i+1:]
-	return UnitTag{name: unitName}, true
+	return UnitTag{tag: UnitTagKind + "-" + unitName}, true
 }
 
 func unitTagSuffixToId(s string) string {
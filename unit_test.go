@@ -5,7 +5,9 @@ package names_test
 
 import (
 	"fmt"
+	stdtesting "testing"
 
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/names"
@@ -19,6 +21,32 @@ func (s *unitSuite) TestUnitTag(c *gc.C) {
 	c.Assert(names.NewUnitTag("wordpress/2").String(), gc.Equals, "unit-wordpress-2")
 }
 
+func (s *unitSuite) TestUnitTagService(c *gc.C) {
+	c.Assert(names.NewUnitTag("wordpress/2").Service(), gc.Equals, names.NewServiceTag("wordpress"))
+	c.Assert(names.NewUnitTag("rabbitmq-server/123").Service(), gc.Equals, names.NewServiceTag("rabbitmq-server"))
+	c.Assert(names.UnitTag{}.Service(), gc.Equals, names.ServiceTag{})
+}
+
+func (s *unitSuite) TestZeroUnitTag(c *gc.C) {
+	var tag names.UnitTag
+	c.Assert(tag.String(), gc.Equals, "unit-")
+	c.Assert(tag.Id(), gc.Equals, "")
+}
+
+func BenchmarkUnitTagString(b *stdtesting.B) {
+	tag := names.NewUnitTag("rabbitmq-server/123")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = tag.String()
+	}
+}
+
+func (s *unitSuite) TestUnitNumber(c *gc.C) {
+	c.Assert(names.NewUnitTag("wordpress/2").Number(), gc.Equals, 2)
+	c.Assert(names.NewUnitTag("rabbitmq-server/123").Number(), gc.Equals, 123)
+	c.Assert(names.NewUnitTag("foo/0").Number(), gc.Equals, 0)
+}
+
 var unitNameTests = []struct {
 	pattern string
 	valid   bool
@@ -102,3 +130,66 @@ func (s *unitSuite) TestParseUnitTag(c *gc.C) {
 		c.Check(got, gc.Equals, t.expected)
 	}
 }
+
+func (s *unitSuite) TestUnitsOf(c *gc.C) {
+	tags := []names.Tag{
+		names.NewUnitTag("mysql/1"),
+		names.NewServiceTag("mysql"),
+		names.NewUnitTag("mysql-router/0"),
+		names.NewMachineTag("0"),
+		names.NewUnitTag("mysql/0"),
+	}
+	c.Assert(names.UnitsOf(names.NewServiceTag("mysql"), tags), jc.DeepEquals, []names.UnitTag{
+		names.NewUnitTag("mysql/1"),
+		names.NewUnitTag("mysql/0"),
+	})
+	c.Assert(names.UnitsOf(names.NewServiceTag("wordpress"), tags), gc.HasLen, 0)
+}
+
+func (s *unitSuite) TestUnitPathKey(c *gc.C) {
+	for i, test := range []struct {
+		key  string
+		unit string
+		err  string
+	}{
+		{key: "mysql-0", unit: "mysql/0"},
+		{key: "rabbitmq-server-12", unit: "rabbitmq-server/12"},
+		{key: "mysql/0", err: `"mysql/0" is not a valid unit path key`},
+		{key: "mysql", err: `"mysql" is not a valid unit path key`},
+		{key: "0-mysql", err: `"0-mysql" is not a valid unit path key`},
+	} {
+		c.Logf("test %d: %q", i, test.key)
+		tag, err := names.UnitTagFromPathKey(test.key)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, names.NewUnitTag(test.unit))
+		c.Check(tag.PathKey(), gc.Equals, test.key)
+	}
+}
+
+func (s *unitSuite) TestNewUnitTagFromParts(c *gc.C) {
+	for i, test := range []struct {
+		service string
+		n       int
+		expect  string
+		err     string
+	}{
+		{service: "mysql", n: 0, expect: "mysql/0"},
+		{service: "rabbitmq-server", n: 12, expect: "rabbitmq-server/12"},
+		{service: "mysql-0", n: 1, err: `"mysql-0" is not a valid service name`},
+		{service: "", n: 1, err: `"" is not a valid service name`},
+		{service: "mysql", n: -1, err: `-1 is not a valid unit number`},
+	} {
+		c.Logf("test %d: %q %d", i, test.service, test.n)
+		tag, err := names.NewUnitTagFromParts(test.service, test.n)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, names.NewUnitTag(test.expect))
+	}
+}
@@ -5,12 +5,20 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"strings"
 )
 
 const (
 	UserTagKind     = "user"
 	LocalUserDomain = "local"
+
+	// EveryoneUserName is the id of the pseudo-user standing for
+	// every user authenticated by the external identity provider.
+	EveryoneUserName = "everyone@external"
+
+	// AdminUserName is the name of the local user
+	// created to administer a new controller.
+	AdminUserName = "admin"
 )
 
 var (
@@ -18,16 +26,68 @@ var (
 	// domains. Is that deliberate?
 	// https://github.com/juju/names/issues/54
 	validUserPart = "[a-zA-Z0-9][a-zA-Z0-9.+-]*[a-zA-Z0-9]"
-	validName     = regexp.MustCompile(fmt.Sprintf("^(?P<name>%s)(?:@(?P<domain>%s))?$", validUserPart, validUserPart))
-	validUserName = regexp.MustCompile("^" + validUserPart + "$")
+	validName     = newLazyRegexp(fmt.Sprintf("^(?P<name>%s)(?:@(?P<domain>%s))?$", validUserPart, validUserPart))
+	validUserName = newLazyRegexp("^" + validUserPart + "$")
+
+	validExternalUserName = newLazyRegexp("^[a-zA-Z0-9_](?:[a-zA-Z0-9._+-]*[a-zA-Z0-9_])?$")
 )
 
+// maxExternalUserNameLength holds the maximum length
+// of names valid under ExternalUserNames.
+const maxExternalUserNameLength = 255
+
+// UserNameProfile selects the rules by which user names are validated.
+type UserNameProfile int
+
+const (
+	// LocalUserNames applies the rules for users held in Juju's
+	// own user database. See IsValidUserName.
+	LocalUserNames UserNameProfile = iota
+
+	// ExternalUserNames applies the looser rules for users provided
+	// by an external identity provider. In addition to the names
+	// allowed for local users, these rules allow single character
+	// names and names containing "_", as found in email-like
+	// identities, up to 255 characters long. Such users must be
+	// in a domain other than the local domain.
+	ExternalUserNames
+)
+
+// IsValidName returns whether name is a valid
+// user name (without domain) under the profile.
+func (p UserNameProfile) IsValidName(name string) bool {
+	if p == ExternalUserNames {
+		return len(name) <= maxExternalUserNameLength && validExternalUserName.MatchString(name)
+	}
+	return IsValidUserName(name)
+}
+
 // IsValidUser returns whether id is a valid user id.
 // Valid users may or may not be qualified with an
 // @domain suffix. Examples of valid users include
 // bob, bob@local, bob@somewhere-else, 0-a-f@123.
+// Users in domains other than the local domain may
+// have any name valid under ExternalUserNames.
 func IsValidUser(id string) bool {
-	return validName.MatchString(id)
+	_, _, ok := splitUserId(id)
+	return ok
+}
+
+// splitUserId returns the name and domain parts
+// of the user id, and whether the id is valid.
+func splitUserId(id string) (name, domain string, ok bool) {
+	if parts := validName.FindStringSubmatch(id); parts != nil {
+		return parts[1], parts[2], true
+	}
+	i := strings.LastIndex(id, "@")
+	if i == -1 {
+		return "", "", false
+	}
+	name, domain = id[:i], id[i+1:]
+	if domain == LocalUserDomain || !IsValidUserDomain(domain) || !ExternalUserNames.IsValidName(name) {
+		return "", "", false
+	}
+	return name, domain, true
 }
 
 // IsValidUserName returns whether the given
@@ -65,6 +125,22 @@ func (t UserTag) Id() string {
 	return t.name + "@" + t.domain
 }
 
+// Validate implements SelfValidator.
+func (t UserTag) Validate() error { return validateTag(t) }
+
+// PathSegment returns the tag in a form safe for use
+// as a single path segment (see TagFromPathSegment).
+func (t UserTag) PathSegment() string { return pathSegment(t) }
+
+// RedactedString implements Redacter. Users outside the local
+// domain have their domain masked.
+func (t UserTag) RedactedString() string {
+	if t.IsLocal() {
+		return t.String()
+	}
+	return UserTagKind + "-" + t.name + "@" + RedactedMask
+}
+
 // Name returns the name part of the user name
 // without its associated domain.
 func (t UserTag) Name() string { return t.name }
@@ -77,6 +153,13 @@ func (t UserTag) Canonical() string {
 	return t.name + "@" + t.Domain()
 }
 
+// EqualsIgnoreCase returns whether the tag and other represent the
+// same user. User names and domains are compared case-insensitively,
+// and a user with no domain is the same as that user @local.
+func (t UserTag) EqualsIgnoreCase(other UserTag) bool {
+	return strings.EqualFold(t.Canonical(), other.Canonical())
+}
+
 // IsLocal returns true if the tag represents a local user.
 func (t UserTag) IsLocal() bool {
 	return t.Domain() == LocalUserDomain
@@ -108,11 +191,38 @@ func (t UserTag) WithDomain(domain string) UserTag {
 // NewUserTag returns the tag for the user with the given name.
 // It panics if the user name does not satisfy IsValidUser.
 func NewUserTag(userName string) UserTag {
-	parts := validName.FindStringSubmatch(userName)
-	if len(parts) != 3 {
+	name, domain, ok := splitUserId(userName)
+	if !ok {
 		panic(fmt.Sprintf("invalid user tag %q", userName))
 	}
-	return UserTag{name: parts[1], domain: parts[2]}
+	return UserTag{name: name, domain: domain}
+}
+
+// NewUserTagWithDomain returns the tag for the user with the given
+// name in the given domain. An empty domain is treated as the local
+// domain. Unlike NewUserTag and WithDomain, it returns an error
+// rather than panicking if the name or domain is invalid.
+func NewUserTagWithDomain(name, domain string) (UserTag, error) {
+	return NewUserTagWithProfile(LocalUserNames, name, domain)
+}
+
+// NewUserTagWithProfile is like NewUserTagWithDomain, but validates
+// the name under the given profile. Use ExternalUserNames for users
+// provided by an external identity provider.
+func NewUserTagWithProfile(profile UserNameProfile, name, domain string) (UserTag, error) {
+	if !profile.IsValidName(name) {
+		return UserTag{}, fmt.Errorf("invalid user name %q", name)
+	}
+	if domain == "" {
+		domain = LocalUserDomain
+	}
+	if !IsValidUserDomain(domain) {
+		return UserTag{}, fmt.Errorf("invalid user domain %q", domain)
+	}
+	if !IsValidUser(name + "@" + domain) {
+		return UserTag{}, fmt.Errorf("user name %q is not valid in domain %q", name, domain)
+	}
+	return UserTag{name: name, domain: domain}, nil
 }
 
 // NewLocalUserTag returns the tag for a local user with the given name.
@@ -135,3 +245,36 @@ func ParseUserTag(tag string) (UserTag, error) {
 	}
 	return ut, nil
 }
+
+// UserIdFromTagString returns the id of the user with the given
+// tag string, with its domain, if any, as written in the tag.
+func UserIdFromTagString(s string) (string, error) {
+	return idFromTagString(UserTagKind, s)
+}
+
+// EveryoneTag returns the tag of the pseudo-user
+// named by EveryoneUserName.
+func EveryoneTag() UserTag {
+	return NewUserTag(EveryoneUserName)
+}
+
+// IsEveryoneTag returns whether tag is that of the
+// pseudo-user named by EveryoneUserName.
+func IsEveryoneTag(tag Tag) bool {
+	user, ok := tag.(UserTag)
+	return ok && user.Canonical() == EveryoneUserName
+}
+
+// AdminUserTag returns the tag of the local
+// user named by AdminUserName.
+func AdminUserTag() UserTag {
+	return NewLocalUserTag(AdminUserName)
+}
+
+// IsSystemUser returns whether tag is that of the local
+// user named by AdminUserName, with or without an explicit
+// @local domain.
+func IsSystemUser(tag Tag) bool {
+	user, ok := tag.(UserTag)
+	return ok && user.IsLocal() && user.Name() == AdminUserName
+}
@@ -5,6 +5,7 @@ package names_test
 
 import (
 	"fmt"
+	"strings"
 
 	gc "gopkg.in/check.v1"
 
@@ -133,6 +134,15 @@ func (s *userSuite) TestIsValidUser(c *gc.C) {
 		{"bar@", false},
 		{"@local", false},
 		{"not/valid", false},
+		{"bar_foo@external", true},
+		{"a@external", true},
+		{"_@external", true},
+		{"a.b_c+d@external", true},
+		{"a.@external", false},
+		{"bar_foo@local", false},
+		{"a@local", false},
+		{"bar_foo@", false},
+		{"bar_foo@ex_ternal", false},
 	} {
 		c.Logf("test %d: %s", i, t.string)
 		c.Assert(names.IsValidUser(t.string), gc.Equals, t.expect, gc.Commentf("%s", t.string))
@@ -239,3 +249,113 @@ func (s *userSuite) TestNewLocalUserTag(c *gc.C) {
 	c.Assert(func() { names.NewLocalUserTag("") }, gc.PanicMatches, `invalid user name ""`)
 	c.Assert(func() { names.NewLocalUserTag("!@#") }, gc.PanicMatches, `invalid user name "!@#"`)
 }
+
+func (s *userSuite) TestNewUserTagWithDomain(c *gc.C) {
+	for i, test := range []struct {
+		name   string
+		domain string
+		expect string
+		err    string
+	}{
+		{name: "bob", domain: "external", expect: "bob@external"},
+		{name: "bob", domain: "", expect: "bob@local"},
+		{name: "bob", domain: names.LocalUserDomain, expect: "bob@local"},
+		{name: "bob@foo", domain: "external", err: `invalid user name "bob@foo"`},
+		{name: "bob", domain: "@foo", err: `invalid user domain "@foo"`},
+	} {
+		c.Logf("test %d: %q %q", i, test.name, test.domain)
+		tag, err := names.NewUserTagWithDomain(test.name, test.domain)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, gc.IsNil)
+		c.Check(tag, gc.Equals, names.NewUserTag(test.expect))
+		c.Check(tag.IsLocal(), gc.Equals, test.domain == "" || test.domain == names.LocalUserDomain)
+	}
+}
+
+func (s *userSuite) TestEqualsIgnoreCase(c *gc.C) {
+	for i, test := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{"bob", "bob", true},
+		{"bob", "Bob", true},
+		{"bob", "BOB@local", true},
+		{"bob@Example.com", "bob@example.com", true},
+		{"bob@foo", "bob", false},
+		{"bob", "bobby", false},
+	} {
+		c.Logf("test %d: %q %q", i, test.a, test.b)
+		a, b := names.NewUserTag(test.a), names.NewUserTag(test.b)
+		c.Check(a.EqualsIgnoreCase(b), gc.Equals, test.equal)
+		c.Check(b.EqualsIgnoreCase(a), gc.Equals, test.equal)
+	}
+}
+
+func (s *userSuite) TestUserNameProfiles(c *gc.C) {
+	for i, test := range []struct {
+		name     string
+		local    bool
+		external bool
+	}{
+		{"bob", true, true},
+		{"bob.smith+jazz", true, true},
+		{"b", false, true},
+		{"bob_smith", false, true},
+		{"bob@foo", false, false},
+		{"bob.", false, false},
+		{"", false, false},
+		{strings.Repeat("b", 255), true, true},
+		{strings.Repeat("b", 256), true, false},
+	} {
+		c.Logf("test %d: %q", i, test.name)
+		c.Check(names.LocalUserNames.IsValidName(test.name), gc.Equals, test.local)
+		c.Check(names.ExternalUserNames.IsValidName(test.name), gc.Equals, test.external)
+	}
+}
+
+func (s *userSuite) TestNewUserTagWithProfile(c *gc.C) {
+	tag, err := names.NewUserTagWithProfile(names.ExternalUserNames, "bob_smith", "external")
+	c.Assert(err, gc.IsNil)
+	c.Assert(tag.Name(), gc.Equals, "bob_smith")
+	c.Assert(tag.Domain(), gc.Equals, "external")
+	c.Assert(tag.String(), gc.Equals, "user-bob_smith@external")
+
+	parsed, err := names.ParseUserTag(tag.String())
+	c.Assert(err, gc.IsNil)
+	c.Assert(parsed, gc.Equals, tag)
+
+	_, err = names.NewUserTagWithProfile(names.LocalUserNames, "bob_smith", "external")
+	c.Assert(err, gc.ErrorMatches, `invalid user name "bob_smith"`)
+
+	_, err = names.NewUserTagWithProfile(names.ExternalUserNames, "bob_smith", "")
+	c.Assert(err, gc.ErrorMatches, `user name "bob_smith" is not valid in domain "local"`)
+}
+
+func (s *userSuite) TestWellKnownUsers(c *gc.C) {
+	c.Assert(names.EveryoneTag().String(), gc.Equals, "user-everyone@external")
+	c.Assert(names.AdminUserTag().String(), gc.Equals, "user-admin@local")
+
+	for i, test := range []struct {
+		tag      names.Tag
+		everyone bool
+		system   bool
+	}{
+		{tag: names.EveryoneTag(), everyone: true},
+		{tag: names.NewUserTag("everyone@external"), everyone: true},
+		{tag: names.NewUserTag("everyone"), everyone: false},
+		{tag: names.NewUserTag("everyone@local"), everyone: false},
+		{tag: names.AdminUserTag(), system: true},
+		{tag: names.NewUserTag("admin"), system: true},
+		{tag: names.NewUserTag("admin@external"), system: false},
+		{tag: names.NewUserTag("bob"), system: false},
+		{tag: names.NewMachineTag("0"), system: false},
+		{tag: nil, system: false},
+	} {
+		c.Logf("test %d: %v", i, test.tag)
+		c.Check(names.IsEveryoneTag(test.tag), gc.Equals, test.everyone)
+		c.Check(names.IsSystemUser(test.tag), gc.Equals, test.system)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/juju/utils"
+)
+
+// uuidLen is the length of a UUID in string form.
+const uuidLen = 36
+
+// IsValidUUID returns whether s is a UUID in canonical string form:
+// 32 lower case hexadecimal digits in groups of 8, 4, 4, 4 and 12
+// separated by hyphens. It accepts the same strings as
+// utils.IsValidUUIDString, but does not allocate.
+func IsValidUUID(s string) bool {
+	if len(s) != uuidLen {
+		return false
+	}
+	for i := 0; i < uuidLen; i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isLowerHex(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lowerUUID returns s in lower case if it is a UUID written
+// in upper or mixed case, and s unchanged otherwise. UUIDs are
+// often copied into tags from sources, such as cloud consoles,
+// that write them in upper case.
+func lowerUUID(s string) string {
+	if len(s) != uuidLen || IsValidUUID(s) {
+		return s
+	}
+	if lower := strings.ToLower(s); IsValidUUID(lower) {
+		return lower
+	}
+	return s
+}
+
+// containsUUID returns whether s contains a UUID in canonical string
+// form. It is the historical rule for model and environment ids.
+func containsUUID(s string) bool {
+	for i := 0; i+uuidLen <= len(s); i++ {
+		if IsValidUUID(s[i : i+uuidLen]) {
+			return true
+		}
+	}
+	return false
+}
+
+// uuidFromString is like utils.UUIDFromString, but does not
+// allocate. It reports whether s is a valid UUID.
+func uuidFromString(s string) (utils.UUID, bool) {
+	var uuid utils.UUID
+	if !IsValidUUID(s) {
+		return uuid, false
+	}
+	// Every group has an even number of digits,
+	// so no byte straddles a hyphen.
+	for i, j := 0, 0; i < uuidLen; {
+		if s[i] == '-' {
+			i++
+			continue
+		}
+		uuid[j] = fromHex(s[i])<<4 | fromHex(s[i+1])
+		i += 2
+		j++
+	}
+	return uuid, true
+}
+
+func isLowerHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f'
+}
+
+func fromHex(c byte) byte {
+	if c <= '9' {
+		return c - '0'
+	}
+	return c - 'a' + 10
+}
+
+// newUUID returns a new random (version 4) UUID
+// in string form, reading random bytes from source.
+func newUUID(source io.Reader) (string, error) {
+	var uuid [16]byte
+	if _, err := io.ReadFull(source, uuid[:]); err != nil {
+		return "", err
+	}
+	// Set the version (4) and variant (RFC 4122) bits.
+	uuid[6] = 0x40 | uuid[6]&0x0f
+	uuid[8] = 0x80 | uuid[8]&0x3f
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], uuid[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], uuid[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], uuid[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], uuid[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], uuid[10:])
+	return string(buf), nil
+}
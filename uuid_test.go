@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type uuidSuite struct{}
+
+var _ = gc.Suite(&uuidSuite{})
+
+var uuidTests = []string{
+	"",
+	"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+	"00000000-0000-0000-0000-000000000000",
+	"ffffffff-ffff-ffff-ffff-ffffffffffff",
+	"F47AC10B-58CC-4372-A567-0E02B2C3D479",
+	"f47ac10b-58cc-4372-a567-0e02b2c3d47",
+	"f47ac10b-58cc-4372-a567-0e02b2c3d4790",
+	"f47ac10b58cc-4372-a567-0e02b2c3d479-",
+	"f47ac10b-58cc-4372-a567_0e02b2c3d479",
+	"g47ac10b-58cc-4372-a567-0e02b2c3d479",
+	"xf47ac10b-58cc-4372-a567-0e02b2c3d479",
+	"f47ac10b-58cc-4372-a567-0e02b2c3d479x",
+	"model-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+}
+
+func (s *uuidSuite) TestIsValidUUIDMatchesUtils(c *gc.C) {
+	for i, id := range uuidTests {
+		c.Logf("test %d: %q", i, id)
+		c.Check(names.IsValidUUID(id), gc.Equals, utils.IsValidUUIDString(id))
+	}
+}
+
+// historicalModelUUID is the rule model and environment
+// ids were validated against before IsValidUUID existed.
+var historicalModelUUID = regexp.MustCompile(`[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}`)
+
+func (s *uuidSuite) TestIsValidModelUnchanged(c *gc.C) {
+	for i, id := range uuidTests {
+		c.Logf("test %d: %q", i, id)
+		c.Check(names.IsValidModel(id), gc.Equals, historicalModelUUID.MatchString(id))
+		c.Check(names.IsValidEnvironment(id), gc.Equals, historicalModelUUID.MatchString(id))
+	}
+}
+
+func (s *uuidSuite) TestUUIDTagsMatchUtils(c *gc.C) {
+	for i, id := range uuidTests {
+		expect, err := utils.UUIDFromString(id)
+		if err != nil {
+			continue
+		}
+		c.Logf("test %d: %q", i, id)
+		c.Check(names.NewActionTag(id).ID, gc.Equals, expect)
+		c.Check(names.NewIPAddressTag(id).Id(), gc.Equals, expect.String())
+	}
+}
+
+func (s *uuidSuite) TestIsValidUUIDAllocations(c *gc.C) {
+	allocs := testing.AllocsPerRun(100, func() {
+		names.IsValidUUID("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+		names.IsValidModel("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	})
+	c.Assert(allocs, gc.Equals, 0.0)
+}
+
+func BenchmarkIsValidUUID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		names.IsValidUUID("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	}
+}
+
+func BenchmarkUtilsIsValidUUIDString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		utils.IsValidUUIDString("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	}
+}
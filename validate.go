@@ -0,0 +1,46 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+)
+
+// SelfValidator is implemented by tags that can check their own
+// validity. All the tags in this package implement it, so that
+// layers decoding tags, such as from JSON or BSON, can check them
+// generically once decoded.
+type SelfValidator interface {
+	Validate() error
+}
+
+// Validate returns an error if the given tag is not valid. Tags that
+// implement SelfValidator validate themselves; other tags are valid
+// if their string form is accepted by ParseTag.
+func Validate(tag Tag) error {
+	switch tag := tag.(type) {
+	case nil:
+		return fmt.Errorf("nil tag is not valid")
+	case SelfValidator:
+		return tag.Validate()
+	}
+	if IsValidTag(tag.String()) {
+		return nil
+	}
+	kind := tag.Kind()
+	if !validKinds(kind) {
+		kind = ""
+	}
+	return invalidTagError(tag.String(), kind)
+}
+
+// validateTag returns an error if the id of the
+// given tag is not valid for its kind.
+func validateTag(tag Tag) error {
+	kind := tag.Kind()
+	if h, ok := kindHandlers[kind]; ok && h.isValidId(tag.Id()) {
+		return nil
+	}
+	return invalidTagError(tag.String(), kind)
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+	"github.com/juju/names/namestest"
+)
+
+type validateSuite struct{}
+
+var _ = gc.Suite(&validateSuite{})
+
+func (s *validateSuite) TestValidTags(c *gc.C) {
+	for i, entry := range namestest.Corpus() {
+		if !entry.Valid {
+			continue
+		}
+		c.Logf("test %d: %q", i, entry.Tag)
+		tag, err := names.ParseTag(entry.Tag)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Implements, new(names.SelfValidator))
+		c.Check(tag.(names.SelfValidator).Validate(), jc.ErrorIsNil)
+		c.Check(names.Validate(tag), jc.ErrorIsNil)
+	}
+}
+
+func (s *validateSuite) TestInvalidTags(c *gc.C) {
+	// Zero tags, as left by a decoder given no value, are invalid.
+	for i, tag := range []names.Tag{
+		names.CharmTag{},
+		names.EnvironTag{},
+		names.FilesystemTag{},
+		names.MachineTag{},
+		names.ModelTag{},
+		names.PayloadTag{},
+		names.RelationTag{},
+		names.ServiceTag{},
+		names.SpaceTag{},
+		names.StorageTag{},
+		names.SubnetTag{},
+		names.UnitTag{},
+		names.UserTag{},
+		names.VolumeTag{},
+		names.DiscontinuedTag{},
+		names.ServiceTag{Name: "1mysql"},
+	} {
+		c.Logf("test %d: %#v", i, tag)
+		err := names.Validate(tag)
+		c.Check(err, jc.DeepEquals, names.InvalidTagError(tag.String(), tag.Kind()))
+		c.Check(errors.Is(err, names.ErrInvalidTag), jc.IsTrue)
+	}
+}
+
+func (s *validateSuite) TestDiscontinuedTag(c *gc.C) {
+	p := names.Parser{DiscontinuedPolicy: names.AcceptDiscontinued}
+	tag, err := p.ParseTag("network-foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names.Validate(tag), jc.ErrorIsNil)
+}
+
+// otherTag is a tag implemented outside this package,
+// which does not implement SelfValidator.
+type otherTag struct {
+	kind, id string
+}
+
+func (t otherTag) String() string { return t.kind + "-" + t.id }
+func (t otherTag) Kind() string   { return t.kind }
+func (t otherTag) Id() string     { return t.id }
+
+func (s *validateSuite) TestOtherTags(c *gc.C) {
+	c.Check(names.Validate(otherTag{"machine", "0"}), jc.ErrorIsNil)
+	c.Check(names.Validate(otherTag{"machine", "x"}), gc.ErrorMatches, `"machine-x" is not a valid machine tag`)
+	c.Check(names.Validate(otherTag{"foo", "x"}), gc.ErrorMatches, `"foo-x" is not a valid tag`)
+	c.Check(names.Validate(nil), gc.ErrorMatches, `nil tag is not valid`)
+}
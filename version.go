@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names
+
+import (
+	"fmt"
+)
+
+// SerializationVersion identifies a version of the tag wire format.
+// Clients talking to controllers that understand an older version
+// should negotiate it and convert tags accordingly with TagForVersion.
+type SerializationVersion int
+
+const (
+	// SerializationV1 is the original wire format, in which
+	// models are represented by environment tags.
+	SerializationV1 SerializationVersion = 1
+
+	// SerializationV2 is the wire format in which models are
+	// represented by model tags.
+	SerializationV2 SerializationVersion = 2
+
+	// CurrentSerializationVersion is the most recent version
+	// of the wire format.
+	CurrentSerializationVersion = SerializationV2
+)
+
+// ParseTagVersion parses a tag string in any known format and returns
+// the tag as it is represented in version v of the wire format.
+func ParseTagVersion(s string, v SerializationVersion) (Tag, error) {
+	tag, err := ParseTag(s)
+	if err != nil {
+		return nil, err
+	}
+	return TagForVersion(tag, v)
+}
+
+// TagForVersion returns the given tag as it is represented in
+// version v of the wire format.
+func TagForVersion(tag Tag, v SerializationVersion) (Tag, error) {
+	switch v {
+	case SerializationV1:
+		if tag, ok := tag.(ModelTag); ok {
+			return tag.ToEnvironTag(), nil
+		}
+	case SerializationV2:
+		if tag, ok := tag.(EnvironTag); ok {
+			return tag.ToModelTag(), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown tag serialization version %d", v)
+	}
+	return tag, nil
+}
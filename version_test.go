@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package names_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/names"
+)
+
+type versionSuite struct{}
+
+var _ = gc.Suite(&versionSuite{})
+
+const versionUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func (s *versionSuite) TestParseTagVersion(c *gc.C) {
+	for i, test := range []struct {
+		tag     string
+		version names.SerializationVersion
+		expect  names.Tag
+		err     string
+	}{{
+		tag:     "model-" + versionUUID,
+		version: names.SerializationV1,
+		expect:  names.NewEnvironTag(versionUUID),
+	}, {
+		tag:     "environment-" + versionUUID,
+		version: names.SerializationV1,
+		expect:  names.NewEnvironTag(versionUUID),
+	}, {
+		tag:     "environment-" + versionUUID,
+		version: names.SerializationV2,
+		expect:  names.NewModelTag(versionUUID),
+	}, {
+		tag:     "model-" + versionUUID,
+		version: names.CurrentSerializationVersion,
+		expect:  names.NewModelTag(versionUUID),
+	}, {
+		tag:     "service-mysql",
+		version: names.SerializationV1,
+		expect:  names.NewServiceTag("mysql"),
+	}, {
+		tag:     "service-mysql",
+		version: 99,
+		err:     "unknown tag serialization version 99",
+	}, {
+		tag:     "model-foo",
+		version: names.SerializationV1,
+		err:     `"model-foo" is not a valid model tag`,
+	}} {
+		c.Logf("test %d: %q v%d", i, test.tag, test.version)
+		tag, err := names.ParseTagVersion(test.tag, test.version)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(tag, gc.IsNil)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, test.expect)
+	}
+}
@@ -5,7 +5,7 @@ package names
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -13,15 +13,19 @@ const VolumeTagKind = "volume"
 
 // Volumes may be bound to a machine, meaning that the volume cannot
 // exist without that machine. We encode this in the tag to allow
-var validVolume = regexp.MustCompile("^(" + MachineSnippet + "/)?" + NumberSnippet + "$")
+// the volume to be identified with its machine, and removed with it.
+// Volumes on Kubernetes models may likewise be bound to a unit.
+var validVolume = newLazyRegexp("^(" + MachineSnippet + "/|" + ServiceSnippet + "/" + NumberSnippet + "/)?" + NumberSnippet + "$")
 
 type VolumeTag struct {
 	id string
 }
 
-func (t VolumeTag) String() string { return t.Kind() + "-" + t.id }
-func (t VolumeTag) Kind() string   { return VolumeTagKind }
-func (t VolumeTag) Id() string     { return volumeTagSuffixToId(t.id) }
+func (t VolumeTag) String() string      { return t.Kind() + "-" + t.id }
+func (t VolumeTag) Kind() string        { return VolumeTagKind }
+func (t VolumeTag) Id() string          { return volumeTagSuffixToId(t.id) }
+func (t VolumeTag) Validate() error     { return validateTag(t) }
+func (t VolumeTag) PathSegment() string { return pathSegment(t) }
 
 // NewVolumeTag returns the tag for the volume with the given ID.
 // It will panic if the given volume ID is not valid.
@@ -33,6 +37,31 @@ func NewVolumeTag(id string) VolumeTag {
 	return tag
 }
 
+// NewMachineVolumeTag returns the tag for volume number n
+// bound to the given machine.
+func NewMachineVolumeTag(machine MachineTag, n int) (VolumeTag, error) {
+	if n < 0 {
+		return VolumeTag{}, fmt.Errorf("%d is not a valid volume number", n)
+	}
+	tag, ok := tagFromVolumeId(machine.Id() + "/" + strconv.Itoa(n))
+	if !ok {
+		return VolumeTag{}, fmt.Errorf("%q is not a valid machine ID", machine.Id())
+	}
+	return tag, nil
+}
+
+// Machine returns the machine the volume is bound to,
+// and whether the volume is bound to a machine.
+func (t VolumeTag) Machine() (MachineTag, bool) {
+	return VolumeMachine(t)
+}
+
+// Number returns the volume's number, without any machine
+// component, e.g. "5" for volume 0/5.
+func (t VolumeTag) Number() string {
+	return t.id[strings.LastIndex(t.id, "-")+1:]
+}
+
 // ParseVolumeTag parses a volume tag string.
 func ParseVolumeTag(volumeTag string) (VolumeTag, error) {
 	tag, err := ParseTag(volumeTag)
@@ -48,7 +77,7 @@ func ParseVolumeTag(volumeTag string) (VolumeTag, error) {
 
 // IsValidVolume returns whether id is a valid volume ID.
 func IsValidVolume(id string) bool {
-	return validVolume.MatchString(id)
+	return isValidScopedStorageId(validVolume, id)
 }
 
 // VolumeMachine returns the machine component of the volume
@@ -57,12 +86,41 @@ func IsValidVolume(id string) bool {
 func VolumeMachine(tag VolumeTag) (MachineTag, bool) {
 	id := tag.Id()
 	pos := strings.LastIndex(id, "/")
-	if pos == -1 {
+	if pos == -1 || isUnitScopedStorageId(id) {
 		return MachineTag{}, false
 	}
 	return NewMachineTag(id[:pos]), true
 }
 
+// Unit returns the unit the volume is bound to,
+// and whether the volume is bound to a unit.
+func (t VolumeTag) Unit() (UnitTag, bool) {
+	return scopedStorageUnit(t.Id())
+}
+
+// SortKey returns a key by which volume tags may be ordered
+// naturally: unbound volumes first, then volumes bound to machines,
+// ordered as by MachineTag.SortKey, then volumes bound to units,
+// ordered as by UnitTag.SortKey; volumes with the same scope are
+// ordered numerically. The key is only meaningful for comparison
+// with other keys.
+func (t VolumeTag) SortKey() string {
+	return scopedStorageSortKey(t.Id())
+}
+
+// NewUnitVolumeTag returns the tag for volume number n
+// bound to the given unit.
+func NewUnitVolumeTag(unit UnitTag, n int) (VolumeTag, error) {
+	if n < 0 {
+		return VolumeTag{}, fmt.Errorf("%d is not a valid volume number", n)
+	}
+	tag, ok := tagFromVolumeId(unit.Id() + "/" + strconv.Itoa(n))
+	if !ok {
+		return VolumeTag{}, fmt.Errorf("%q is not a valid unit name", unit.Id())
+	}
+	return tag, nil
+}
+
 func tagFromVolumeId(id string) (VolumeTag, bool) {
 	if !IsValidVolume(id) {
 		return VolumeTag{}, false
@@ -72,5 +130,5 @@ func tagFromVolumeId(id string) (VolumeTag, bool) {
 }
 
 func volumeTagSuffixToId(s string) string {
-	return strings.Replace(s, "-", "/", -1)
+	return scopedStorageTagSuffixToId(s)
 }
@@ -27,13 +27,20 @@ func (s *volumeSuite) TestVolumeNameValidity(c *gc.C) {
 	assertVolumeNameInvalid(c, "")
 	assertVolumeNameInvalid(c, "one")
 	assertVolumeNameInvalid(c, "#")
-	assertVolumeNameInvalid(c, "0/0/0") // 0/0 is not a valid machine ID
+	assertVolumeNameInvalid(c, "0/0/0")     // 0/0 is not a valid machine ID
+	assertVolumeNameInvalid(c, "0/foo/1/2") // foo is not a known container type
+	assertVolumeNameValid(c, "mariadb/0/2")
+	assertVolumeNameValid(c, "my-db/10/0")
+	assertVolumeNameInvalid(c, "mariadb/2")  // mariadb is not a valid machine ID
+	assertVolumeNameInvalid(c, "mariadb/0/") // missing volume number
 }
 
 func (s *volumeSuite) TestParseVolumeTag(c *gc.C) {
 	assertParseVolumeTag(c, "volume-0", names.NewVolumeTag("0"))
 	assertParseVolumeTag(c, "volume-88", names.NewVolumeTag("88"))
 	assertParseVolumeTag(c, "volume-0-lxc-0-88", names.NewVolumeTag("0/lxc/0/88"))
+	assertParseVolumeTag(c, "volume-mariadb-0-2", names.NewVolumeTag("mariadb/0/2"))
+	assertParseVolumeTag(c, "volume-my-db-10-0", names.NewVolumeTag("my-db/10/0"))
 	assertParseVolumeTagInvalid(c, "", names.InvalidTagError("", ""))
 	assertParseVolumeTagInvalid(c, "one", names.InvalidTagError("one", ""))
 	assertParseVolumeTagInvalid(c, "volume-", names.InvalidTagError("volume-", names.VolumeTagKind))
@@ -44,6 +51,54 @@ func (s *volumeSuite) TestVolumeMachine(c *gc.C) {
 	assertVolumeMachine(c, "0/0", names.NewMachineTag("0"))
 	assertVolumeMachine(c, "0/lxc/0/0", names.NewMachineTag("0/lxc/0"))
 	assertVolumeNoMachine(c, "0")
+	assertVolumeNoMachine(c, "mariadb/0/2")
+}
+
+func (s *volumeSuite) TestVolumeUnit(c *gc.C) {
+	unit, ok := names.NewVolumeTag("my-db/10/2").Unit()
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(unit, gc.Equals, names.NewUnitTag("my-db/10"))
+
+	_, ok = names.NewVolumeTag("0/2").Unit()
+	c.Assert(ok, gc.Equals, false)
+	_, ok = names.NewVolumeTag("2").Unit()
+	c.Assert(ok, gc.Equals, false)
+}
+
+func (s *volumeSuite) TestNewUnitVolumeTag(c *gc.C) {
+	tag, err := names.NewUnitVolumeTag(names.NewUnitTag("mariadb/0"), 2)
+	c.Assert(err, gc.IsNil)
+	c.Assert(tag, gc.Equals, names.NewVolumeTag("mariadb/0/2"))
+	c.Assert(tag.String(), gc.Equals, "volume-mariadb-0-2")
+
+	_, err = names.NewUnitVolumeTag(names.NewUnitTag("mariadb/0"), -1)
+	c.Assert(err, gc.ErrorMatches, `-1 is not a valid volume number`)
+	_, err = names.NewUnitVolumeTag(names.UnitTag{}, 1)
+	c.Assert(err, gc.ErrorMatches, `"" is not a valid unit name`)
+}
+
+func (s *volumeSuite) TestVolumeTagMachineAndNumber(c *gc.C) {
+	tag := names.NewVolumeTag("0/lxc/1/5")
+	m, ok := tag.Machine()
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(m, gc.Equals, names.NewMachineTag("0/lxc/1"))
+	c.Assert(tag.Number(), gc.Equals, "5")
+
+	tag = names.NewVolumeTag("12")
+	_, ok = tag.Machine()
+	c.Assert(ok, gc.Equals, false)
+	c.Assert(tag.Number(), gc.Equals, "12")
+}
+
+func (s *volumeSuite) TestNewMachineVolumeTag(c *gc.C) {
+	tag, err := names.NewMachineVolumeTag(names.NewMachineTag("0/kvm/2"), 5)
+	c.Assert(err, gc.IsNil)
+	c.Assert(tag, gc.Equals, names.NewVolumeTag("0/kvm/2/5"))
+
+	_, err = names.NewMachineVolumeTag(names.NewMachineTag("0"), -1)
+	c.Assert(err, gc.ErrorMatches, `-1 is not a valid volume number`)
+	_, err = names.NewMachineVolumeTag(names.MachineTag{}, 1)
+	c.Assert(err, gc.ErrorMatches, `"" is not a valid machine ID`)
 }
 
 func assertVolumeMachine(c *gc.C, id string, expect names.MachineTag) {